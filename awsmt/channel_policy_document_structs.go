@@ -0,0 +1,30 @@
+package awsmt
+
+import "github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+
+type channelPolicyDocumentModel struct {
+	ChannelArn        *string                       `tfsdk:"channel_arn"`
+	Json              jsontypes.Normalized          `tfsdk:"json"`
+	PublicGetManifest *bool                         `tfsdk:"public_get_manifest"`
+	Statements        []channelPolicyStatementModel `tfsdk:"statements"`
+}
+
+type channelPolicyStatementModel struct {
+	Actions    []string                      `tfsdk:"actions"`
+	Conditions []channelPolicyConditionModel `tfsdk:"conditions"`
+	Effect     *string                       `tfsdk:"effect"`
+	Principals []channelPolicyPrincipalModel `tfsdk:"principals"`
+	Resources  []string                      `tfsdk:"resources"`
+	Sid        *string                       `tfsdk:"sid"`
+}
+
+type channelPolicyPrincipalModel struct {
+	Identifiers []string `tfsdk:"identifiers"`
+	Type        *string  `tfsdk:"type"`
+}
+
+type channelPolicyConditionModel struct {
+	Test     *string  `tfsdk:"test"`
+	Values   []string `tfsdk:"values"`
+	Variable *string  `tfsdk:"variable"`
+}
@@ -1,23 +1,89 @@
 package awsmt
 
 import (
-	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	datasourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type channelModel struct {
-	ID               types.String         `tfsdk:"id"`
-	Arn              types.String         `tfsdk:"arn"`
-	Name             *string              `tfsdk:"name"`
-	ChannelState     *string              `tfsdk:"channel_state"`
-	CreationTime     types.String         `tfsdk:"creation_time"`
-	FillerSlate      *fillerSlateModel    `tfsdk:"filler_slate"`
-	LastModifiedTime types.String         `tfsdk:"last_modified_time"`
-	Outputs          []outputsModel       `tfsdk:"outputs"`
-	PlaybackMode     *string              `tfsdk:"playback_mode"`
-	Policy           jsontypes.Normalized `tfsdk:"policy"`
-	Tags             map[string]*string   `tfsdk:"tags"`
-	Tier             *string              `tfsdk:"tier"`
+	ID                  types.String       `tfsdk:"id"`
+	Arn                 types.String       `tfsdk:"arn"`
+	Name                *string            `tfsdk:"name"`
+	ChannelState        types.String       `tfsdk:"channel_state"`
+	CreationTime        timestamp          `tfsdk:"creation_time"`
+	EnforceChannelState types.Bool         `tfsdk:"enforce_channel_state"`
+	FillerSlate         *fillerSlateModel  `tfsdk:"filler_slate"`
+	LastModifiedTime    timestamp          `tfsdk:"last_modified_time"`
+	Outputs             []outputsModel     `tfsdk:"outputs"`
+	PlaybackMode        *string            `tfsdk:"playback_mode"`
+	Policy              iamPolicy          `tfsdk:"policy"`
+	Tags                map[string]*string `tfsdk:"tags"`
+	Tier                *string            `tfsdk:"tier"`
+	Timeouts            timeouts.Value     `tfsdk:"timeouts"`
+}
+
+// @ADR
+// Context: Resources and data sources shared their models, so the data sources used the timeouts attribute of the
+// resource package, which only worked as long as resource and data source schema attributes stayed interchangeable.
+// Decision: We decided to give the data sources the timeouts attribute of the data source package, and models of
+// their own, which are converted to the resource models to be read with the helpers of the resources.
+// Consequences: An attribute added to a resource model that the data source also has must be added to the data
+// source model and its conversions.
+
+// channelDataSourceModel holds the attributes of channelModel that apply to the data source, which cannot enforce a
+// channel state, with the timeouts of a data source.
+type channelDataSourceModel struct {
+	ID               types.String             `tfsdk:"id"`
+	Arn              types.String             `tfsdk:"arn"`
+	Name             *string                  `tfsdk:"name"`
+	ChannelState     types.String             `tfsdk:"channel_state"`
+	CreationTime     timestamp                `tfsdk:"creation_time"`
+	FillerSlate      *fillerSlateModel        `tfsdk:"filler_slate"`
+	LastModifiedTime timestamp                `tfsdk:"last_modified_time"`
+	Outputs          []outputsModel           `tfsdk:"outputs"`
+	PlaybackMode     *string                  `tfsdk:"playback_mode"`
+	Policy           iamPolicy                `tfsdk:"policy"`
+	Tags             map[string]*string       `tfsdk:"tags"`
+	Tier             *string                  `tfsdk:"tier"`
+	Timeouts         datasourcetimeouts.Value `tfsdk:"timeouts"`
+}
+
+// channelModel returns the data source model without its timeouts, so that it can be read with the helpers of the
+// resource.
+func (m channelDataSourceModel) channelModel() channelModel {
+	return channelModel{
+		ID:               m.ID,
+		Arn:              m.Arn,
+		Name:             m.Name,
+		ChannelState:     m.ChannelState,
+		CreationTime:     m.CreationTime,
+		FillerSlate:      m.FillerSlate,
+		LastModifiedTime: m.LastModifiedTime,
+		Outputs:          m.Outputs,
+		PlaybackMode:     m.PlaybackMode,
+		Policy:           m.Policy,
+		Tags:             m.Tags,
+		Tier:             m.Tier,
+	}
+}
+
+func newChannelDataSourceModel(m channelModel, dataSourceTimeouts datasourcetimeouts.Value) channelDataSourceModel {
+	return channelDataSourceModel{
+		ID:               m.ID,
+		Arn:              m.Arn,
+		Name:             m.Name,
+		ChannelState:     m.ChannelState,
+		CreationTime:     m.CreationTime,
+		FillerSlate:      m.FillerSlate,
+		LastModifiedTime: m.LastModifiedTime,
+		Outputs:          m.Outputs,
+		PlaybackMode:     m.PlaybackMode,
+		Policy:           m.Policy,
+		Tags:             m.Tags,
+		Tier:             m.Tier,
+		Timeouts:         dataSourceTimeouts,
+	}
 }
 
 type fillerSlateModel struct {
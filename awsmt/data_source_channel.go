@@ -3,15 +3,18 @@ package awsmt
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
-	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"strings"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ datasource.DataSource              = &dataSourceChannel{}
-	_ datasource.DataSourceWithConfigure = &dataSourceChannel{}
+	_ datasource.DataSource                     = &dataSourceChannel{}
+	_ datasource.DataSourceWithConfigure        = &dataSourceChannel{}
+	_ datasource.DataSourceWithConfigValidators = &dataSourceChannel{}
 )
 
 func DataSourceChannel() datasource.DataSource {
@@ -19,21 +22,21 @@ func DataSourceChannel() datasource.DataSource {
 }
 
 type dataSourceChannel struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
 }
 
 func (d *dataSourceChannel) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_channel"
 }
 
-func (d *dataSourceChannel) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *dataSourceChannel) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id":            computedString,
-			"arn":           computedString,
-			"name":          requiredString,
+			"arn":           optionalComputedString,
+			"name":          optionalComputedString,
 			"channel_state": computedString,
-			"creation_time": computedString,
+			"creation_time": computedTimestamp,
 			"filler_slate": schema.SingleNestedAttribute{
 				Computed: true,
 				Attributes: map[string]schema.Attribute{
@@ -41,7 +44,7 @@ func (d *dataSourceChannel) Schema(_ context.Context, _ datasource.SchemaRequest
 					"vod_source_name":      computedString,
 				},
 			},
-			"last_modified_time": computedString,
+			"last_modified_time": computedTimestamp,
 			"outputs": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -73,56 +76,74 @@ func (d *dataSourceChannel) Schema(_ context.Context, _ datasource.SchemaRequest
 			},
 			"policy": schema.StringAttribute{
 				Computed:   true,
-				CustomType: jsontypes.NormalizedType{},
+				CustomType: iamPolicyType{},
 			},
-			"tags": computedMap,
-			"tier": computedString,
+			"tags":     optionalComputedMap,
+			"timeouts": timeouts.Attributes(ctx),
+			"tier":     computedString,
 		},
 	}
 }
 
+func (d *dataSourceChannel) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("name"),
+			path.MatchRoot("arn"),
+			path.MatchRoot("tags"),
+		),
+	}
+}
+
 func (d *dataSourceChannel) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.MediaTailor)
+	d.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (d *dataSourceChannel) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data channelModel
+	var data channelDataSourceModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	channelName := data.Name
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	channelName, err := getChannelNameFromSelectors(ctx, d.client, data)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error while looking up channel", err, nil)
+		return
+	}
 
-	channel, err := d.client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: channelName})
+	channel, err := d.client.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: channelName})
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing channel "+*channelName, err.Error())
+		addAPIError(&resp.Diagnostics, "Error while describing channel "+*channelName, err, nil)
 		return
 	}
 
-	policy, err := d.client.GetChannelPolicy(&mediatailor.GetChannelPolicyInput{ChannelName: channelName})
-	if err != nil && !strings.Contains(err.Error(), "NotFound") {
-		resp.Diagnostics.AddError(
-			"Error while getting the channel policy "+err.Error(),
-			err.Error(),
-		)
+	policy, err := d.client.GetChannelPolicyWithContext(ctx, &mediatailor.GetChannelPolicyInput{ChannelName: channelName})
+	if err != nil && !isNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error while getting the policy of channel "+*channelName, err, nil)
 		return
 	}
 
+	state := data.channelModel()
 	if policy.Policy != nil {
-		data.Policy = jsontypes.NewNormalizedPointerValue(policy.Policy)
+		state.Policy = newIamPolicyPointerValue(policy.Policy)
 	}
 
-	if channel.ChannelState != nil {
-		data.ChannelState = channel.ChannelState
-	}
+	state.ChannelState = types.StringPointerValue(channel.ChannelState)
 
-	data = readChannelToState(data, *channel)
+	data = newChannelDataSourceModel(readChannelToState(state, *channel), data.Timeouts)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,113 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+)
+
+var (
+	_ datasource.DataSource = &dataSourceChannelPolicyDocument{}
+)
+
+func DataSourceChannelPolicyDocument() datasource.DataSource {
+	return &dataSourceChannelPolicyDocument{}
+}
+
+// dataSourceChannelPolicyDocument renders a channel policy without calling MediaTailor, so it does not need the
+// client.
+type dataSourceChannelPolicyDocument struct{}
+
+func (d *dataSourceChannelPolicyDocument) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_policy_document"
+}
+
+func (d *dataSourceChannelPolicyDocument) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"channel_arn": optionalString,
+			"json": schema.StringAttribute{
+				Computed:   true,
+				CustomType: jsontypes.NormalizedType{},
+			},
+			"public_get_manifest": optionalBool,
+			"statements": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"actions": schema.ListAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueStringsAre(
+									stringvalidator.RegexMatches(regexp.MustCompile(`^mediatailor:[A-Za-z*]+$`), "must be a MediaTailor action, e.g. mediatailor:GetManifest"),
+								),
+							},
+						},
+						"conditions": schema.ListNestedAttribute{
+							Optional: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"test": requiredString,
+									"values": schema.ListAttribute{
+										Required:    true,
+										ElementType: types.StringType,
+										Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+									},
+									"variable": requiredString,
+								},
+							},
+						},
+						"effect": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("Allow", "Deny"),
+							},
+						},
+						"principals": schema.ListNestedAttribute{
+							Required:   true,
+							Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"identifiers": schema.ListAttribute{
+										Required:    true,
+										ElementType: types.StringType,
+										Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+									},
+									"type": requiredString,
+								},
+							},
+						},
+						"resources": optionalList,
+						"sid":       optionalString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *dataSourceChannelPolicyDocument) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data channelPolicyDocumentModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	document, err := renderChannelPolicyDocument(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Error while rendering channel policy document", err.Error())
+		return
+	}
+	data.Json = jsontypes.NewNormalizedValue(document)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
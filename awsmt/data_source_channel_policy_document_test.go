@@ -0,0 +1,98 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestRenderChannelPolicyDocument(t *testing.T) {
+	channelArn := "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"
+	publicGetManifest := true
+	effect := "Deny"
+	principalType := "AWS"
+	test := "StringEquals"
+	variable := "aws:SourceVpce"
+
+	document, err := renderChannelPolicyDocument(channelPolicyDocumentModel{
+		ChannelArn:        &channelArn,
+		PublicGetManifest: &publicGetManifest,
+		Statements: []channelPolicyStatementModel{{
+			Actions:    []string{"mediatailor:GetManifest"},
+			Effect:     &effect,
+			Principals: []channelPolicyPrincipalModel{{Type: &principalType, Identifiers: []string{"arn:aws:iam::123456789012:root"}}},
+			Conditions: []channelPolicyConditionModel{
+				{Test: &test, Variable: &variable, Values: []string{"vpce-1", "vpce-2"}},
+				{Test: &test, Variable: &variable, Values: []string{"vpce-2", "vpce-3"}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"Version":"2012-10-17","Statement":[` +
+		`{"Sid":"AllowAnonymousGetManifest","Effect":"Allow","Principal":"*","Action":"mediatailor:GetManifest","Resource":"arn:aws:mediatailor:eu-central-1:123456789012:channel/test"},` +
+		`{"Effect":"Deny","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"mediatailor:GetManifest","Resource":"arn:aws:mediatailor:eu-central-1:123456789012:channel/test","Condition":{"StringEquals":{"aws:SourceVpce":["vpce-1","vpce-2","vpce-3"]}}}]}`
+	if document != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, document)
+	}
+}
+
+func TestRenderChannelPolicyDocumentErrors(t *testing.T) {
+	publicGetManifest := true
+	channelArn := "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"
+	for _, data := range []channelPolicyDocumentModel{
+		{},
+		{PublicGetManifest: &publicGetManifest},
+		{Statements: []channelPolicyStatementModel{{Actions: []string{"mediatailor:GetManifest"}}}},
+		{ChannelArn: &channelArn, Statements: []channelPolicyStatementModel{{Actions: []string{"mediatailor:GetManifest"}}}},
+	} {
+		if _, err := renderChannelPolicyDocument(data); err == nil {
+			t.Errorf("expected an error for %+v", data)
+		}
+	}
+}
+
+func TestAccChannelPolicyDocumentDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: channelPolicyDocumentDS(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awsmt_channel_policy_document.test", "json", `{"Version":"2012-10-17","Statement":[{"Sid":"AllowAnonymousGetManifest","Effect":"Allow","Principal":"*","Action":"mediatailor:GetManifest","Resource":"arn:aws:mediatailor:eu-central-1:123456789012:channel/test"}]}`),
+				),
+			},
+			{
+				Config:      channelPolicyDocumentDSInvalidAction(),
+				ExpectError: regexp.MustCompile("must be a MediaTailor action"),
+			},
+		},
+	})
+}
+
+func channelPolicyDocumentDS() string {
+	return `
+				data "awsmt_channel_policy_document" "test" {
+  					channel_arn = "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"
+  					public_get_manifest = true
+				}
+				`
+}
+
+func channelPolicyDocumentDSInvalidAction() string {
+	return `
+				data "awsmt_channel_policy_document" "test" {
+  					channel_arn = "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"
+  					statements = [{
+    					actions = ["s3:GetObject"]
+    					principals = [{
+      					type = "*"
+      					identifiers = ["*"]
+    					}]
+  					}]
+				}
+				`
+}
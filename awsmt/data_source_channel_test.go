@@ -14,11 +14,11 @@ func TestAccChannelDataSourceBasic(t *testing.T) {
 			{
 				Config: basicChannelDSHLS(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "id", "test"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "id", "tf-acc-test-channel"),
 					resource.TestMatchResourceAttr("data.awsmt_channel.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:channel\/.*$`)),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "test"),
-					resource.TestMatchResourceAttr("data.awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestMatchResourceAttr("data.awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "tf-acc-test-channel"),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "channel_state", "STOPPED"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "playback_mode", "LOOP"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "tier", "BASIC"),
@@ -40,15 +40,15 @@ func TestAccChannelDataSourceFillerSlateLinear(t *testing.T) {
 			{
 				Config: basicChannelDSHLSWithSlate(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "id", "test"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "id", "tf-acc-test-channel"),
 					resource.TestMatchResourceAttr("data.awsmt_channel.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:channel\/.*$`)),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "test"),
-					resource.TestMatchResourceAttr("data.awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestMatchResourceAttr("data.awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "tf-acc-test-channel"),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "channel_state", "STOPPED"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "playback_mode", "LINEAR"),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "filler_slate.source_location_name", "test_source_location"),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "filler_slate.vod_source_name", "vod_source_example"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "filler_slate.source_location_name", "tf-acc-test-source-location"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "filler_slate.vod_source_name", "tf-acc-test-vod-source"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "tier", "BASIC"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "tags.Environment", "dev"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "outputs.0.manifest_name", "default"),
@@ -73,10 +73,28 @@ func TestAccChannelDataSourceErrors(t *testing.T) {
 	})
 }
 
+func TestAccChannelDataSourceSelectors(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: channelDSSelectors(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awsmt_channel.by_arn", "name", "tf-acc-test-channel"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.by_arn", "playback_mode", "LOOP"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.by_tags", "name", "tf-acc-test-channel"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.by_tags", "tags.Selector", "test"),
+				),
+			},
+		},
+	})
+}
+
 func basicChannelDSHLS() string {
 	return `
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-test-channel"
   					channel_state = "STOPPED"
   					outputs = [{
     					manifest_name                = "default"
@@ -109,20 +127,20 @@ func basicChannelDSHLSWithSlate() string {
 						source_group = "default"
     					type = "HLS"
   					}]
-  					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "vod_source_example"
+  					source_location_name = awsmt_source_location.tf-acc-test-source-location.name
+  					name = "tf-acc-test-vod-source"
 					tags = {"Environment": "dev"}
 				}
 				data "awsmt_vod_source" "data_test" {
-  					source_location_name = awsmt_source_location.test_source_location.name
+  					source_location_name = awsmt_source_location.tf-acc-test-source-location.name
   					name = awsmt_vod_source.test.name
 				}
 
 				output "vod_source_out" {
   					value = data.awsmt_vod_source.data_test
 				}
-				resource "awsmt_source_location" "test_source_location"{
-  					name = "test_source_location"
+				resource "awsmt_source_location" "tf-acc-test-source-location"{
+  					name = "tf-acc-test-source-location"
   					http_configuration = {
     					base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   					}
@@ -131,13 +149,13 @@ func basicChannelDSHLSWithSlate() string {
   					}
 				}
 				data "awsmt_source_location" "test" {
-  					name = awsmt_source_location.test_source_location.name
+  					name = awsmt_source_location.tf-acc-test-source-location.name
 				}
 				output "awsmt_source_location" {
   					value = data.awsmt_source_location.test
 				}
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-test-channel"
   					channel_state = "STOPPED"
   					outputs = [{
     					manifest_name                = "default"
@@ -149,7 +167,7 @@ func basicChannelDSHLSWithSlate() string {
   					}]
   					playback_mode = "LINEAR"
 					filler_slate = {
-						source_location_name = awsmt_source_location.test_source_location.name
+						source_location_name = awsmt_source_location.tf-acc-test-source-location.name
 						vod_source_name = awsmt_vod_source.test.name
 					}
   					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/test\"}]}"
@@ -169,7 +187,7 @@ func basicChannelDSHLSWithSlate() string {
 func channelErrorDS() string {
 	return `
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-test-channel"
   					channel_state = "STOPPED"
   					outputs = [{
     					manifest_name                = "default"
@@ -193,3 +211,29 @@ func channelErrorDS() string {
 				}
 				`
 }
+
+func channelDSSelectors() string {
+	return `
+				resource "awsmt_channel" "test"  {
+  					name = "tf-acc-test-channel"
+  					outputs = [{
+    					manifest_name                = "default"
+						source_group                 = "default"
+    					hls_playlist_settings = {
+							ad_markup_type = ["DATERANGE"]
+							manifest_window_seconds = 30
+						}
+  					}]
+  					playback_mode = "LOOP"
+  					tier = "BASIC"
+					tags = {"Selector": "test"}
+				}
+
+				data "awsmt_channel" "by_arn" {
+  					arn = awsmt_channel.test.arn
+				}
+				data "awsmt_channel" "by_tags" {
+  					tags = {"Selector": awsmt_channel.test.name}
+				}
+				`
+}
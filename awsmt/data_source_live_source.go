@@ -2,8 +2,11 @@ package awsmt
 
 import (
 	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/aws/aws-sdk-go/service/mediatailor"
@@ -11,8 +14,9 @@ import (
 )
 
 var (
-	_ datasource.DataSource              = &dataSourceLiveSource{}
-	_ datasource.DataSourceWithConfigure = &dataSourceLiveSource{}
+	_ datasource.DataSource                     = &dataSourceLiveSource{}
+	_ datasource.DataSourceWithConfigure        = &dataSourceLiveSource{}
+	_ datasource.DataSourceWithConfigValidators = &dataSourceLiveSource{}
 )
 
 func DataSourceLiveSource() datasource.DataSource {
@@ -20,19 +24,19 @@ func DataSourceLiveSource() datasource.DataSource {
 }
 
 type dataSourceLiveSource struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
 }
 
 func (d *dataSourceLiveSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_live_source"
 }
 
-func (d *dataSourceLiveSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *dataSourceLiveSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id":            computedString,
-			"arn":           computedString,
-			"creation_time": computedString,
+			"arn":           optionalComputedString,
+			"creation_time": computedTimestamp,
 			"http_package_configurations": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -48,40 +52,67 @@ func (d *dataSourceLiveSource) Schema(_ context.Context, _ datasource.SchemaRequ
 					},
 				},
 			},
-			"last_modified_time":   computedString,
-			"name":                 requiredString,
-			"source_location_name": requiredString,
-			"tags":                 computedMap,
+			"last_modified_time": computedTimestamp,
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("source_location_name")),
+				},
+			},
+			"source_location_name": optionalComputedString,
+			"tags":                 optionalComputedMap,
+			"timeouts":             timeouts.Attributes(ctx),
 		},
 	}
 }
 
+func (d *dataSourceLiveSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("name"),
+			path.MatchRoot("arn"),
+			path.MatchRoot("tags"),
+		),
+	}
+}
+
 func (d *dataSourceLiveSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.MediaTailor)
+	d.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (d *dataSourceLiveSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data liveSourceModel
+	var data liveSourceDataSourceModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	sourceLocationName := data.SourceLocationName
-	liveSourceName := data.Name
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceLocationName, liveSourceName, err := getLiveSourceNamesFromSelectors(ctx, d.client, data)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error while looking up live source", err, nil)
+		return
+	}
 
-	liveSource, err := d.client.DescribeLiveSource(&mediatailor.DescribeLiveSourceInput{SourceLocationName: sourceLocationName, LiveSourceName: liveSourceName})
+	liveSource, err := d.client.DescribeLiveSourceWithContext(ctx, &mediatailor.DescribeLiveSourceInput{SourceLocationName: sourceLocationName, LiveSourceName: liveSourceName})
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing live source", err.Error())
+		addAPIError(&resp.Diagnostics, "Error while describing live source", err, nil)
 		return
 	}
 
-	data = readLiveSourceToPlan(data, mediatailor.CreateLiveSourceOutput(*liveSource))
+	data = newLiveSourceDataSourceModel(readLiveSourceToPlan(data.liveSourceModel(), mediatailor.CreateLiveSourceOutput(*liveSource)), data.Timeouts)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -14,15 +14,15 @@ func TestAccLiveSourceDataSourceBasic(t *testing.T) {
 			{
 				Config: liveSourceDS(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "id", "test_source_location,live_source_example"),
+					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "id", "tf-acc-test-source-location,tf-acc-test-live-source"),
 					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:liveSource\/.*$`)),
-					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
 					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "http_package_configurations.0.path", "/"),
 					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "http_package_configurations.0.source_group", "default"),
 					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "name", "live_source_example"),
-					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "name", "tf-acc-test-live-source"),
+					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "source_location_name", "tf-acc-test-source-location"),
 					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "tags.Environment", "dev"),
 				),
 			},
@@ -51,21 +51,21 @@ func liveSourceDS() string {
     					source_group = "default"
     					type = "HLS"
   					}]
-  					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "live_source_example"
+  					source_location_name = awsmt_source_location.tf-acc-test-source-location.name
+  					name = "tf-acc-test-live-source"
 					tags = {"Environment": "dev"}
 				}
 
 				data "awsmt_live_source" "data_test" {
-  					source_location_name = awsmt_source_location.test_source_location.name
+  					source_location_name = awsmt_source_location.tf-acc-test-source-location.name
   					name = awsmt_live_source.test.name
 				}
 
 				output "live_source_out" {
   					value = data.awsmt_live_source.data_test
 				}
-			resource "awsmt_source_location" "test_source_location"{
-  				name = "test_source_location"
+			resource "awsmt_source_location" "tf-acc-test-source-location"{
+  				name = "tf-acc-test-source-location"
   				http_configuration = {
     				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   				}
@@ -74,7 +74,7 @@ func liveSourceDS() string {
   				}
 			}
 			data "awsmt_source_location" "test" {
-  				name = awsmt_source_location.test_source_location.name
+  				name = awsmt_source_location.tf-acc-test-source-location.name
 			}
 			output "awsmt_source_location" {
   				value = data.awsmt_source_location.test
@@ -90,21 +90,21 @@ func liveSourceError() string {
     					source_group = "default"
     					type = "HLS"
   					}]
-  					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "live_source_example"
+  					source_location_name = awsmt_source_location.tf-acc-test-source-location.name
+  					name = "tf-acc-test-live-source"
 					tags = {"Environment": "dev"}
 				}
 
 				data "awsmt_live_source" "data_test" {
-  					source_location_name = awsmt_source_location.test_source_location.name
+  					source_location_name = awsmt_source_location.tf-acc-test-source-location.name
   					name = "testingError"
 				}
 
 				output "live_source_out" {
   					value = data.awsmt_live_source.data_test
 				}
-			resource "awsmt_source_location" "test_source_location"{
-  				name = "test_source_location"
+			resource "awsmt_source_location" "tf-acc-test-source-location"{
+  				name = "tf-acc-test-source-location"
   				http_configuration = {
     				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   				}
@@ -113,7 +113,7 @@ func liveSourceError() string {
   				}
 			}
 			data "awsmt_source_location" "test" {
-  				name = awsmt_source_location.test_source_location.name
+  				name = awsmt_source_location.tf-acc-test-source-location.name
 			}
 			output "awsmt_source_location" {
   				value = data.awsmt_source_location.test
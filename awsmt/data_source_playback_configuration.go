@@ -3,14 +3,18 @@ package awsmt
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ datasource.DataSource              = &dataSourcePlaybackConfiguration{}
-	_ datasource.DataSourceWithConfigure = &dataSourcePlaybackConfiguration{}
+	_ datasource.DataSource                     = &dataSourcePlaybackConfiguration{}
+	_ datasource.DataSourceWithConfigure        = &dataSourcePlaybackConfiguration{}
+	_ datasource.DataSourceWithConfigValidators = &dataSourcePlaybackConfiguration{}
 )
 
 func DataSourcePlaybackConfiguration() datasource.DataSource {
@@ -18,14 +22,14 @@ func DataSourcePlaybackConfiguration() datasource.DataSource {
 }
 
 type dataSourcePlaybackConfiguration struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
 }
 
 func (d *dataSourcePlaybackConfiguration) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_playback_configuration"
 }
 
-func (d *dataSourcePlaybackConfiguration) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *dataSourcePlaybackConfiguration) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id":                     computedString,
@@ -68,7 +72,16 @@ func (d *dataSourcePlaybackConfiguration) Schema(_ context.Context, _ datasource
 					"origin_manifest_type":     computedString,
 				},
 			},
-			"hls_configuration_manifest_endpoint_prefix": computedString,
+			"hls_configuration": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"manifest_endpoint_prefix": computedString,
+				},
+			},
+			"hls_configuration_manifest_endpoint_prefix": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Use hls_configuration.manifest_endpoint_prefix instead. This attribute will be removed in the next major version.",
+			},
 			"live_pre_roll_configuration": schema.SingleNestedAttribute{
 				Computed: true,
 				Attributes: map[string]schema.Attribute{
@@ -76,7 +89,16 @@ func (d *dataSourcePlaybackConfiguration) Schema(_ context.Context, _ datasource
 					"max_duration_seconds":   computedInt64,
 				},
 			},
-			"log_configuration_percent_enabled": computedInt64,
+			"log_configuration": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"percent_enabled": computedInt64,
+				},
+			},
+			"log_configuration_percent_enabled": schema.Int64Attribute{
+				Computed:           true,
+				DeprecationMessage: "Use log_configuration.percent_enabled instead. This attribute will be removed in the next major version.",
+			},
 			"manifest_processing_rules": schema.SingleNestedAttribute{
 				Computed: true,
 
@@ -89,47 +111,66 @@ func (d *dataSourcePlaybackConfiguration) Schema(_ context.Context, _ datasource
 					},
 				},
 			},
-			"name":                                   requiredString,
+			"name":                                   optionalComputedString,
 			"personalization_threshold_seconds":      computedInt64,
-			"playback_configuration_arn":             computedString,
+			"playback_configuration_arn":             optionalComputedString,
 			"playback_endpoint_prefix":               computedString,
 			"session_initialization_endpoint_prefix": computedString,
 			"slate_ad_url":                           computedString,
-			"tags":                                   computedMap,
+			"tags":                                   optionalComputedMap,
+			"timeouts":                               timeouts.Attributes(ctx),
 			"transcode_profile_name":                 computedString,
 			"video_content_source_url":               computedString,
 		},
 	}
 }
 
+func (d *dataSourcePlaybackConfiguration) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("name"),
+			path.MatchRoot("playback_configuration_arn"),
+			path.MatchRoot("tags"),
+		),
+	}
+}
+
 func (d *dataSourcePlaybackConfiguration) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.MediaTailor)
+	d.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (d *dataSourcePlaybackConfiguration) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data playbackConfigurationModel
+	var data playbackConfigurationDataSourceModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	name := data.Name
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, err := getPlaybackConfigurationNameFromSelectors(ctx, d.client, data)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error while looking up playback configuration", err, nil)
+		return
+	}
 
-	playbackConfiguration, err := d.client.GetPlaybackConfiguration(&mediatailor.GetPlaybackConfigurationInput{Name: name})
+	playbackConfiguration, err := d.client.GetPlaybackConfigurationWithContext(ctx, &mediatailor.GetPlaybackConfigurationInput{Name: name})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while retrieving the playback configuration "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while getting playback configuration "+*name, err, nil)
 		return
 	}
 
-	data = readPlaybackConfigToPlan(data, mediatailor.PutPlaybackConfigurationOutput(*playbackConfiguration))
+	data = newPlaybackConfigurationDataSourceModel(readPlaybackConfigToPlan(data.playbackConfigurationModel(), mediatailor.PutPlaybackConfigurationOutput(*playbackConfiguration)), data.Timeouts)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
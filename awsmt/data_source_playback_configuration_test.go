@@ -14,7 +14,7 @@ func TestAccPlaybackConfigurationDataSourceBasic(t *testing.T) {
 			{
 				Config: playbackConfigDS(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "id", "example-playback-configuration-awsmt"),
+					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "id", "tf-acc-test-playback-configuration"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "ad_decision_server_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "avail_supression.fill_policy", "FULL_AVAIL_ONLY"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "avail_supression.mode", "BEHIND_LIVE_EDGE"),
@@ -27,12 +27,13 @@ func TestAccPlaybackConfigurationDataSourceBasic(t *testing.T) {
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "dash_configuration.origin_manifest_type", "SINGLE_PERIOD"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "live_pre_roll_configuration.ad_decision_server_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "live_pre_roll_configuration.max_duration_seconds", "2"),
-					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "name", "example-playback-configuration-awsmt"),
+					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "name", "tf-acc-test-playback-configuration"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "personalization_threshold_seconds", "2"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "slate_ad_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "tags.Environment", "dev"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "video_content_source_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "log_configuration_percent_enabled", "0"),
+					resource.TestCheckResourceAttr("data.awsmt_playback_configuration.test", "log_configuration.percent_enabled", "0"),
 				),
 			},
 		},
@@ -46,7 +47,7 @@ func TestAccPlaybackConfigurationDataSourceErrors(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config:      plabackConfigDSError(),
-				ExpectError: regexp.MustCompile("Error while retrieving the playback configuration "),
+				ExpectError: regexp.MustCompile("Error while getting playback configuration "),
 			},
 		},
 	})
@@ -81,7 +82,7 @@ func playbackConfigDS() string {
       								enabled = "false"
     							}
   							}
-  							name = "example-playback-configuration-awsmt"
+  							name = "tf-acc-test-playback-configuration"
   							personalization_threshold_seconds = 2
 							slate_ad_url = "https://exampleurl.com/"
   							tags = {"Environment": "dev"}
@@ -127,7 +128,7 @@ func plabackConfigDSError() string {
       								enabled = "false"
     							}
   							}
-  							name = "example-playback-configuration-awsmt"
+  							name = "tf-acc-test-playback-configuration"
   							personalization_threshold_seconds = 2
 							slate_ad_url = "https://exampleurl.com/"
   							tags = {"Environment": "dev"}
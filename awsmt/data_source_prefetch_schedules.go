@@ -0,0 +1,105 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+var (
+	_ datasource.DataSource              = &dataSourcePrefetchSchedules{}
+	_ datasource.DataSourceWithConfigure = &dataSourcePrefetchSchedules{}
+)
+
+func DataSourcePrefetchSchedules() datasource.DataSource {
+	return &dataSourcePrefetchSchedules{}
+}
+
+type dataSourcePrefetchSchedules struct {
+	client mediaTailorClient
+}
+
+func (d *dataSourcePrefetchSchedules) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prefetch_schedules"
+}
+
+func (d *dataSourcePrefetchSchedules) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                          computedString,
+			"playback_configuration_name": requiredString,
+			"prefetch_schedules": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"arn": computedString,
+						"consumption": schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"avail_matching_criteria": schema.ListNestedAttribute{
+									Computed: true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"dynamic_variable": computedString,
+											"operator":         computedString,
+										},
+									},
+								},
+								"end_time":   computedTimestamp,
+								"start_time": computedTimestamp,
+							},
+						},
+						"name":                        computedString,
+						"playback_configuration_name": computedString,
+						"retrieval": schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"dynamic_variables": computedMap,
+								"end_time":          computedTimestamp,
+								"start_time":        computedTimestamp,
+							},
+						},
+						"stream_id": computedString,
+					},
+				},
+			},
+			"stream_id": optionalString,
+			"timeouts":  timeouts.Attributes(ctx),
+		},
+	}
+}
+
+func (d *dataSourcePrefetchSchedules) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(mediaTailorClient)
+}
+
+func (d *dataSourcePrefetchSchedules) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data prefetchSchedulesModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prefetchSchedules, err := listPrefetchSchedules(ctx, d.client, data.PlaybackConfigurationName, data.StreamId)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error while listing prefetch schedules for playback configuration "+*data.PlaybackConfigurationName, err, nil)
+		return
+	}
+
+	data = readPrefetchSchedulesToState(data, prefetchSchedules)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
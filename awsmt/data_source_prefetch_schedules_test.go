@@ -0,0 +1,58 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccPrefetchSchedulesDataSourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: prefetchSchedulesDS(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awsmt_prefetch_schedules.test", "id", "tf-acc-test-prefetch-configuration,stream"),
+					resource.TestCheckResourceAttr("data.awsmt_prefetch_schedules.test", "playback_configuration_name", "tf-acc-test-prefetch-configuration"),
+					resource.TestCheckResourceAttr("data.awsmt_prefetch_schedules.test", "stream_id", "stream"),
+					resource.TestCheckResourceAttr("data.awsmt_prefetch_schedules.test", "prefetch_schedules.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPrefetchSchedulesDataSourceErrors(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "awsmt_prefetch_schedules" "test" {
+							playback_configuration_name = "testing_errors"
+						}`,
+				ExpectError: regexp.MustCompile("Error while listing prefetch schedules"),
+			},
+		},
+	})
+}
+
+func prefetchSchedulesDS() string {
+	return `resource "awsmt_playback_configuration" "r1" {
+  							ad_decision_server_url = "https://exampleurl.com/"
+  							dash_configuration = {
+    							mpd_location = "DISABLED",
+    							origin_manifest_type = "SINGLE_PERIOD"
+  							}
+  							name = "tf-acc-test-prefetch-configuration"
+ 	 						video_content_source_url = "https://exampleurl.com/"
+						}
+
+						data "awsmt_prefetch_schedules" "test" {
+  							playback_configuration_name = awsmt_playback_configuration.r1.name
+							stream_id = "stream"
+						}
+						`
+}
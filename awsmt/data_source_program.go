@@ -0,0 +1,138 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+var (
+	_ datasource.DataSource              = &dataSourceProgram{}
+	_ datasource.DataSourceWithConfigure = &dataSourceProgram{}
+)
+
+func DataSourceProgram() datasource.DataSource {
+	return &dataSourceProgram{}
+}
+
+type dataSourceProgram struct {
+	client mediaTailorClient
+}
+
+func (d *dataSourceProgram) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_program"
+}
+
+func (d *dataSourceProgram) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": computedString,
+			"ad_breaks": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ad_break_metadata": schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"key":   computedString,
+									"value": computedString,
+								},
+							},
+						},
+						"message_type":  computedString,
+						"offset_millis": computedInt64,
+						"slate": schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"source_location_name": computedString,
+								"vod_source_name":      computedString,
+							},
+						},
+						"splice_insert_message": schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"avail_num":         computedInt64,
+								"avails_expected":   computedInt64,
+								"splice_event_id":   computedInt64,
+								"unique_program_id": computedInt64,
+							},
+						},
+						"time_signal_message": schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"segmentation_descriptors": schema.ListNestedAttribute{
+									Computed: true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"segment_num":            computedInt64,
+											"segmentation_event_id":  computedInt64,
+											"segmentation_type_id":   computedInt64,
+											"segmentation_upid":      computedString,
+											"segmentation_upid_type": computedInt64,
+											"segments_expected":      computedInt64,
+											"sub_segment_num":        computedInt64,
+											"sub_segments_expected":  computedInt64,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"arn":          computedString,
+			"channel_name": requiredString,
+			"clip_range": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"end_offset_millis": computedInt64,
+				},
+			},
+			"creation_time":        computedTimestamp,
+			"duration_millis":      computedInt64,
+			"live_source_name":     computedString,
+			"program_name":         requiredString,
+			"scheduled_start_time": computedTimestamp,
+			"source_location_name": computedString,
+			"timeouts":             timeouts.Attributes(ctx),
+			"vod_source_name":      computedString,
+		},
+	}
+}
+
+func (d *dataSourceProgram) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(mediaTailorClient)
+}
+
+func (d *dataSourceProgram) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data programModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	program, err := d.client.DescribeProgramWithContext(ctx, &mediatailor.DescribeProgramInput{ChannelName: data.ChannelName, ProgramName: data.ProgramName})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error while describing program "+*data.ProgramName, err, nil)
+		return
+	}
+
+	data = readProgramToState(data, *program)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,43 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccProgramDataSourceErrors(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      programDSError(),
+				ExpectError: regexp.MustCompile("Error while describing program testing_errors"),
+			},
+		},
+	})
+}
+
+func programDSError() string {
+	return `
+				resource "awsmt_channel" "test"  {
+  					name = "tf-acc-test-channel"
+  					outputs = [{
+    					manifest_name                = "default"
+						source_group                 = "default"
+    					hls_playlist_settings = {
+							ad_markup_type = ["DATERANGE"]
+							manifest_window_seconds = 30
+						}
+  					}]
+  					playback_mode = "LOOP"
+  					tier = "BASIC"
+				}
+
+				data "awsmt_program" "test" {
+  					channel_name = awsmt_channel.test.name
+  					program_name = "testing_errors"
+				}
+				`
+}
@@ -3,15 +3,19 @@ package awsmt
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 var (
-	_ datasource.DataSource              = &dataSourceSourceLocation{}
-	_ datasource.DataSourceWithConfigure = &dataSourceSourceLocation{}
+	_ datasource.DataSource                     = &dataSourceSourceLocation{}
+	_ datasource.DataSourceWithConfigure        = &dataSourceSourceLocation{}
+	_ datasource.DataSourceWithConfigValidators = &dataSourceSourceLocation{}
 )
 
 func DataSourceSourceLocation() datasource.DataSource {
@@ -19,14 +23,14 @@ func DataSourceSourceLocation() datasource.DataSource {
 }
 
 type dataSourceSourceLocation struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
 }
 
 func (d *dataSourceSourceLocation) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_source_location"
 }
 
-func (d *dataSourceSourceLocation) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *dataSourceSourceLocation) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": computedString,
@@ -49,8 +53,8 @@ func (d *dataSourceSourceLocation) Schema(_ context.Context, _ datasource.Schema
 					},
 				},
 			},
-			"arn":           computedString,
-			"creation_time": computedString,
+			"arn":           optionalComputedString,
+			"creation_time": computedTimestamp,
 			"default_segment_delivery_configuration": schema.SingleNestedAttribute{
 				Computed: true,
 				Attributes: map[string]schema.Attribute{
@@ -63,7 +67,7 @@ func (d *dataSourceSourceLocation) Schema(_ context.Context, _ datasource.Schema
 					"base_url": computedString,
 				},
 			},
-			"last_modified_time": computedString,
+			"last_modified_time": computedTimestamp,
 			"segment_delivery_configurations": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -73,37 +77,59 @@ func (d *dataSourceSourceLocation) Schema(_ context.Context, _ datasource.Schema
 					},
 				},
 			},
-			"name": requiredString,
-			"tags": computedMap,
+			"name":     optionalComputedString,
+			"tags":     optionalComputedMap,
+			"timeouts": timeouts.Attributes(ctx),
 		},
 	}
 }
 
+func (d *dataSourceSourceLocation) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("name"),
+			path.MatchRoot("arn"),
+			path.MatchRoot("tags"),
+		),
+	}
+}
+
 func (d *dataSourceSourceLocation) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.MediaTailor)
+	d.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (d *dataSourceSourceLocation) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data sourceLocationModel
+	var data sourceLocationDataSourceModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	sourceLocationName := data.Name
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceLocationName, err := getSourceLocationNameFromSelectors(ctx, d.client, data)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error while looking up source location", err, nil)
+		return
+	}
 
-	sourceLocation, err := d.client.DescribeSourceLocation(&mediatailor.DescribeSourceLocationInput{SourceLocationName: sourceLocationName})
+	sourceLocation, err := d.client.DescribeSourceLocationWithContext(ctx, &mediatailor.DescribeSourceLocationInput{SourceLocationName: sourceLocationName})
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing source location", err.Error())
+		addAPIError(&resp.Diagnostics, "Error while describing source location", err, nil)
 		return
 	}
 
-	data = readSourceLocationToPlan(data, mediatailor.CreateSourceLocationOutput(*sourceLocation))
+	data = newSourceLocationDataSourceModel(readSourceLocationToPlan(data.sourceLocationModel(), mediatailor.CreateSourceLocationOutput(*sourceLocation)), data.Timeouts)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -14,14 +14,14 @@ func TestAccSourceLocationDataSourceBasic(t *testing.T) {
 			{
 				Config: sourceLocationDS(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "id", "test_source_location"),
+					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "id", "tf-acc-test-source-location"),
 					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:sourceLocation\/.*$`)),
-					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
 					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "default_segment_delivery_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/test-img.jpeg"),
 					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
 					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "segment_delivery_configurations.0.base_url", "https://example.com/"),
-					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "name", "test_source_location"),
+					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "name", "tf-acc-test-source-location"),
 				),
 			},
 		},
@@ -41,9 +41,49 @@ func TestAccSourceLocationDataSourceErrors(t *testing.T) {
 	})
 }
 
+func TestAccSourceLocationDataSourceSelectors(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: sourceLocationDSSelectors(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awsmt_source_location.by_arn", "name", "tf-acc-test-source-location"),
+					resource.TestCheckResourceAttr("data.awsmt_source_location.by_arn", "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
+					resource.TestCheckResourceAttr("data.awsmt_source_location.by_tags", "name", "tf-acc-test-source-location"),
+					resource.TestCheckResourceAttr("data.awsmt_source_location.by_tags", "tags.Selector", "tf-acc-test-source-location"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSourceLocationDataSourceSelectorErrors(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "awsmt_source_location" "read" {
+							name = "tf-acc-test-source-location"
+							arn  = "arn:aws:mediatailor:eu-central-1:000000000000:sourceLocation/tf-acc-test-source-location"
+						}`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config: `data "awsmt_source_location" "read" {
+							arn = "arn:aws:mediatailor:eu-central-1:000000000000:channel/test"
+						}`,
+				ExpectError: regexp.MustCompile("expected an ARN for a sourceLocation"),
+			},
+		},
+	})
+}
+
 func sourceLocationDS() string {
-	return `resource "awsmt_source_location" "test_source_location"{
-  							name = "test_source_location"
+	return `resource "awsmt_source_location" "tf-acc-test-source-location"{
+  							name = "tf-acc-test-source-location"
   							http_configuration = {
     							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
   							}
@@ -57,7 +97,7 @@ func sourceLocationDS() string {
 							tags = {"Environment": "dev"}
 						}
 						data "awsmt_source_location" "read" {
-  							name = awsmt_source_location.test_source_location.name
+  							name = awsmt_source_location.tf-acc-test-source-location.name
 						}
 						output "awsmt_source_location" {
   							value = data.awsmt_source_location.read
@@ -66,8 +106,8 @@ func sourceLocationDS() string {
 }
 
 func sourceLocationDSError() string {
-	return `resource "awsmt_source_location" "test_source_location"{
-  							name = "test_source_location"
+	return `resource "awsmt_source_location" "tf-acc-test-source-location"{
+  							name = "tf-acc-test-source-location"
   							http_configuration = {
     							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
   							}
@@ -88,3 +128,20 @@ func sourceLocationDSError() string {
 						}
 `
 }
+
+func sourceLocationDSSelectors() string {
+	return `resource "awsmt_source_location" "tf-acc-test-source-location"{
+  							name = "tf-acc-test-source-location"
+  							http_configuration = {
+    							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
+  							}
+							tags = {"Selector": "tf-acc-test-source-location"}
+						}
+						data "awsmt_source_location" "by_arn" {
+  							arn = awsmt_source_location.tf-acc-test-source-location.arn
+						}
+						data "awsmt_source_location" "by_tags" {
+  							tags = {"Selector": awsmt_source_location.tf-acc-test-source-location.name}
+						}
+`
+}
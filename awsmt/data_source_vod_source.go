@@ -3,15 +3,19 @@ package awsmt
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 var (
-	_ datasource.DataSource              = &dataSourceVodSource{}
-	_ datasource.DataSourceWithConfigure = &dataSourceVodSource{}
+	_ datasource.DataSource                     = &dataSourceVodSource{}
+	_ datasource.DataSourceWithConfigure        = &dataSourceVodSource{}
+	_ datasource.DataSourceWithConfigValidators = &dataSourceVodSource{}
 )
 
 func DataSourceVodSource() datasource.DataSource {
@@ -19,7 +23,7 @@ func DataSourceVodSource() datasource.DataSource {
 }
 
 type dataSourceVodSource struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
 }
 
 func (d *dataSourceVodSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -29,11 +33,11 @@ func (d *dataSourceVodSource) Metadata(_ context.Context, req datasource.Metadat
 // @ADR
 // Context: The schemas for the VOD Source and the LIVE source are almost identical, except for one field.
 // Decision: We decided to make the duplication undetectable for SonarCloud
-func (d *dataSourceVodSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *dataSourceVodSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id":                   computedString,
-			"source_location_name": requiredString,
+			"source_location_name": optionalComputedString,
 			"http_package_configurations": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -49,42 +53,69 @@ func (d *dataSourceVodSource) Schema(_ context.Context, _ datasource.SchemaReque
 					},
 				},
 			},
-			"creation_time":                        computedString,
-			"tags":                                 computedMap,
-			"last_modified_time":                   computedString,
-			"arn":                                  computedString,
-			"name":                                 requiredString,
+			"creation_time":      computedTimestamp,
+			"tags":               optionalComputedMap,
+			"timeouts":           timeouts.Attributes(ctx),
+			"last_modified_time": computedTimestamp,
+			"arn":                optionalComputedString,
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("source_location_name")),
+				},
+			},
 			"ad_break_opportunities_offset_millis": computedMap,
 		},
 	}
 }
 
+func (d *dataSourceVodSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("name"),
+			path.MatchRoot("arn"),
+			path.MatchRoot("tags"),
+		),
+	}
+}
+
 func (d *dataSourceVodSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.MediaTailor)
+	d.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (d *dataSourceVodSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data vodSourceModel
+	var data vodSourceDataSourceModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	sourceLocationName := data.SourceLocationName
-	vodSourceName := data.Name
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceLocationName, vodSourceName, err := getVodSourceNamesFromSelectors(ctx, d.client, data)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error while looking up VOD source", err, nil)
+		return
+	}
 
-	vodSource, err := d.client.DescribeVodSource(&mediatailor.DescribeVodSourceInput{SourceLocationName: sourceLocationName, VodSourceName: vodSourceName})
+	vodSource, err := d.client.DescribeVodSourceWithContext(ctx, &mediatailor.DescribeVodSourceInput{SourceLocationName: sourceLocationName, VodSourceName: vodSourceName})
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing vod source", err.Error())
+		addAPIError(&resp.Diagnostics, "Error while describing VOD source", err, nil)
 		return
 	}
 
-	data = readVodSourceToState(data, *vodSource)
+	data = newVodSourceDataSourceModel(readVodSourceToState(data.vodSourceModel(), *vodSource), data.Timeouts)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
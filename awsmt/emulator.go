@@ -0,0 +1,229 @@
+package awsmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/private/protocol/json/jsonutil"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// @ADR
+// Context: Terraform modules that use the provider could only be tested end-to-end against AWS, which air-gapped CI
+// cannot reach.
+// Decision: We decided to serve the MediaTailor REST-JSON API over HTTP from the in-memory fake. Routes are derived
+// from the SDK operation definitions, and requests and responses are (un)marshalled with the SDK's own JSON protocol
+// code, so the emulator speaks exactly the wire format the provider sends.
+// Consequences: The emulator supports the operations the fake implements. Other operations are answered with an
+// UnknownOperationException. The JSON protocol code lives in the private jsonutil package of the SDK, which is not
+// covered by its compatibility promise, so TestEmulatorJSONProtocol pins the wire format it produces.
+
+// emulatorOperation serves one MediaTailor operation.
+type emulatorOperation struct {
+	method   string
+	segments []string
+	serve    func(r *http.Request, params map[string]string) (interface{}, error)
+}
+
+type emulator struct {
+	operations []emulatorOperation
+	requests   atomic.Int64
+}
+
+// NewEmulator returns an HTTP handler that serves the MediaTailor API from in-memory state. Resources get ARNs in
+// the given region and account.
+func NewEmulator(region, accountID string) http.Handler {
+	backend := newFakeMediaTailor(region, accountID)
+	// The SDK client is only used to look up the HTTP method and path of every operation. It never sends a request.
+	sdk := mediatailor.New(session.Must(session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(region), Credentials: credentials.AnonymousCredentials},
+		SharedConfigState: session.SharedConfigDisable,
+	})))
+
+	return &emulator{operations: []emulatorOperation{
+		emulate(sdk.CreateChannelRequest, backend.CreateChannelWithContext),
+		emulate(sdk.DescribeChannelRequest, backend.DescribeChannelWithContext),
+		emulate(sdk.UpdateChannelRequest, backend.UpdateChannelWithContext),
+		emulate(sdk.DeleteChannelRequest, backend.DeleteChannelWithContext),
+		emulate(sdk.StartChannelRequest, backend.StartChannelWithContext),
+		emulate(sdk.StopChannelRequest, backend.StopChannelWithContext),
+		emulatePages(sdk.ListChannelsRequest, backend.ListChannelsPagesWithContext),
+		emulate(sdk.GetChannelPolicyRequest, backend.GetChannelPolicyWithContext),
+		emulate(sdk.PutChannelPolicyRequest, backend.PutChannelPolicyWithContext),
+		emulate(sdk.DeleteChannelPolicyRequest, backend.DeleteChannelPolicyWithContext),
+		emulate(sdk.CreateProgramRequest, backend.CreateProgramWithContext),
+		emulate(sdk.DescribeProgramRequest, backend.DescribeProgramWithContext),
+		emulate(sdk.DeleteProgramRequest, backend.DeleteProgramWithContext),
+		emulate(sdk.CreateSourceLocationRequest, backend.CreateSourceLocationWithContext),
+		emulate(sdk.DescribeSourceLocationRequest, backend.DescribeSourceLocationWithContext),
+		emulate(sdk.UpdateSourceLocationRequest, backend.UpdateSourceLocationWithContext),
+		emulate(sdk.DeleteSourceLocationRequest, backend.DeleteSourceLocationWithContext),
+		emulatePages(sdk.ListSourceLocationsRequest, backend.ListSourceLocationsPagesWithContext),
+		emulate(sdk.CreateVodSourceRequest, backend.CreateVodSourceWithContext),
+		emulate(sdk.DescribeVodSourceRequest, backend.DescribeVodSourceWithContext),
+		emulate(sdk.UpdateVodSourceRequest, backend.UpdateVodSourceWithContext),
+		emulate(sdk.DeleteVodSourceRequest, backend.DeleteVodSourceWithContext),
+		emulate(sdk.ListVodSourcesRequest, backend.ListVodSourcesWithContext),
+		emulate(sdk.CreateLiveSourceRequest, backend.CreateLiveSourceWithContext),
+		emulate(sdk.DescribeLiveSourceRequest, backend.DescribeLiveSourceWithContext),
+		emulate(sdk.UpdateLiveSourceRequest, backend.UpdateLiveSourceWithContext),
+		emulate(sdk.DeleteLiveSourceRequest, backend.DeleteLiveSourceWithContext),
+		emulate(sdk.ListLiveSourcesRequest, backend.ListLiveSourcesWithContext),
+		emulate(sdk.PutPlaybackConfigurationRequest, backend.PutPlaybackConfigurationWithContext),
+		emulate(sdk.GetPlaybackConfigurationRequest, backend.GetPlaybackConfigurationWithContext),
+		emulate(sdk.DeletePlaybackConfigurationRequest, backend.DeletePlaybackConfigurationWithContext),
+		emulatePages(sdk.ListPlaybackConfigurationsRequest, backend.ListPlaybackConfigurationsPagesWithContext),
+		emulatePages(sdk.ListPrefetchSchedulesRequest, backend.ListPrefetchSchedulesPagesWithContext),
+		emulate(sdk.ListTagsForResourceRequest, backend.ListTagsForResourceWithContext),
+		emulate(sdk.TagResourceRequest, backend.TagResourceWithContext),
+		emulate(sdk.UntagResourceRequest, backend.UntagResourceWithContext),
+	}}
+}
+
+// emulate routes the SDK operation built by newRequest to the given backend call.
+func emulate[I, O any](newRequest func(*I) (*request.Request, *O), call func(aws.Context, *I, ...request.Option) (*O, error)) emulatorOperation {
+	req, _ := newRequest(nil)
+	return emulatorOperation{
+		method:   req.Operation.HTTPMethod,
+		segments: strings.Split(req.Operation.HTTPPath, "/"),
+		serve: func(r *http.Request, params map[string]string) (interface{}, error) {
+			input := new(I)
+			if err := decodeEmulatorRequest(input, r, params); err != nil {
+				return nil, err
+			}
+			return call(r.Context(), input)
+		},
+	}
+}
+
+// emulatePages routes a paginated SDK operation to a backend call that returns every item in a single page.
+func emulatePages[I, O any](newRequest func(*I) (*request.Request, *O), pages func(aws.Context, *I, func(*O, bool) bool, ...request.Option) error) emulatorOperation {
+	return emulate(newRequest, func(ctx aws.Context, input *I, _ ...request.Option) (*O, error) {
+		output := new(O)
+		err := pages(ctx, input, func(page *O, _ bool) bool {
+			output = page
+			return false
+		})
+		return output, err
+	})
+}
+
+// match returns the path parameters if the operation serves the given method and escaped path.
+func (o emulatorOperation) match(method string, segments []string) (map[string]string, bool) {
+	if method != o.method || len(segments) != len(o.segments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, segment := range o.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			value, err := url.PathUnescape(segments[i])
+			if err != nil || value == "" {
+				return nil, false
+			}
+			params[strings.Trim(segment, "{}")] = value
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (e *emulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := fmt.Sprintf("00000000-0000-0000-0000-%012d", e.requests.Add(1))
+	w.Header().Set("X-Amzn-Requestid", requestID)
+	w.Header().Set("Content-Type", "application/json")
+
+	segments := strings.Split(r.URL.EscapedPath(), "/")
+	for _, operation := range e.operations {
+		params, ok := operation.match(r.Method, segments)
+		if !ok {
+			continue
+		}
+		output, err := operation.serve(r, params)
+		if err != nil {
+			writeEmulatorError(w, err)
+			return
+		}
+		body, err := jsonutil.BuildJSON(output)
+		if err != nil {
+			writeEmulatorError(w, err)
+			return
+		}
+		_, _ = w.Write(body)
+		return
+	}
+	writeEmulatorError(w, awserr.NewRequestFailure(awserr.New("UnknownOperationException", fmt.Sprintf("%s %s is not supported by the emulator", r.Method, r.URL.Path), nil), http.StatusNotFound, requestID))
+}
+
+// writeEmulatorError writes an error the way MediaTailor does. Errors that are not request failures, such as invalid
+// parameters, are reported as a BadRequestException.
+func writeEmulatorError(w http.ResponseWriter, err error) {
+	code, message, status := mediatailor.ErrCodeBadRequestException, err.Error(), http.StatusBadRequest
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() != request.InvalidParameterErrCode {
+		code, message = awsErr.Code(), awsErr.Message()
+	}
+	if failure, ok := err.(awserr.RequestFailure); ok {
+		status = failure.StatusCode()
+	}
+	w.Header().Set("X-Amzn-Errortype", code)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+// decodeEmulatorRequest fills the input with the JSON body, the path parameters and the query parameters of the
+// request, as named by the location tags of the SDK input type.
+func decodeEmulatorRequest(input interface{}, r *http.Request, params map[string]string) error {
+	if err := jsonutil.UnmarshalJSON(input, r.Body); err != nil {
+		return awserr.New(mediatailor.ErrCodeBadRequestException, "invalid request body: "+err.Error(), err)
+	}
+
+	value := reflect.ValueOf(input).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := field.Tag.Get("locationName")
+		var values []string
+		switch field.Tag.Get("location") {
+		case "uri":
+			values = []string{params[name]}
+		case "querystring":
+			values = r.URL.Query()[name]
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if err := setEmulatorParameter(value.Field(i), values); err != nil {
+			return awserr.New(mediatailor.ErrCodeBadRequestException, fmt.Sprintf("invalid parameter %s: %s", name, err), err)
+		}
+	}
+	return nil
+}
+
+func setEmulatorParameter(field reflect.Value, values []string) error {
+	switch field.Interface().(type) {
+	case *string:
+		field.Set(reflect.ValueOf(aws.String(values[0])))
+	case *int64:
+		parsed, err := strconv.ParseInt(values[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(aws.Int64(parsed)))
+	case []*string:
+		field.Set(reflect.ValueOf(aws.StringSlice(values)))
+	default:
+		return fmt.Errorf("unsupported parameter type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package awsmt
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/private/protocol/json/jsonutil"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newEmulatorClient starts an emulator and returns an SDK client that sends its requests to it.
+func newEmulatorClient(t *testing.T) (*httptest.Server, *mediatailor.MediaTailor) {
+	t.Helper()
+	server := httptest.NewServer(NewEmulator("eu-central-1", "123456789012"))
+	t.Cleanup(server.Close)
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("eu-central-1"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("test", "test", ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, mediatailor.New(sess)
+}
+
+func TestEmulatorSourcesAndTags(t *testing.T) {
+	_, client := newEmulatorClient(t)
+
+	if _, err := client.CreateSourceLocation(&mediatailor.CreateSourceLocationInput{
+		SourceLocationName: aws.String("location"),
+		HttpConfiguration:  &mediatailor.HttpConfiguration{BaseUrl: aws.String("https://example.com")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	vodSource, err := client.CreateVodSource(&mediatailor.CreateVodSourceInput{
+		SourceLocationName:        aws.String("location"),
+		VodSourceName:             aws.String("vod"),
+		HttpPackageConfigurations: []*mediatailor.HttpPackageConfiguration{{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: aws.String("HLS")}},
+		Tags:                      map[string]*string{"Environment": aws.String("dev"), "Team": aws.String("video")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The ARN of a VOD source contains slashes, which the SDK escapes in the request path.
+	if _, err := client.UntagResource(&mediatailor.UntagResourceInput{ResourceArn: vodSource.Arn, TagKeys: aws.StringSlice([]string{"Environment", "Team"})}); err != nil {
+		t.Fatal(err)
+	}
+	tags, err := client.ListTagsForResource(&mediatailor.ListTagsForResourceInput{ResourceArn: vodSource.Arn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags.Tags) != 0 {
+		t.Errorf("expected the tags to be removed, got %v", tags.Tags)
+	}
+
+	sources, err := client.ListVodSources(&mediatailor.ListVodSourcesInput{SourceLocationName: aws.String("location")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources.Items) != 1 || *sources.Items[0].VodSourceName != "vod" {
+		t.Errorf("unexpected VOD sources %v", sources.Items)
+	}
+}
+
+func TestEmulatorErrors(t *testing.T) {
+	server, client := newEmulatorClient(t)
+
+	_, err := client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: aws.String("missing")})
+	requireErrorCode(t, err, fakeErrCodeNotFound)
+	if failure, ok := err.(awserr.RequestFailure); !ok || failure.StatusCode() != http.StatusNotFound || failure.RequestID() == "" {
+		t.Errorf("expected a 404 response with a request ID, got %v", err)
+	}
+
+	_, err = client.CreateChannel(&mediatailor.CreateChannelInput{
+		ChannelName:  aws.String("test"),
+		Outputs:      fakeChannelInput("test").Outputs,
+		PlaybackMode: aws.String("UNKNOWN"),
+	})
+	requireErrorCode(t, err, mediatailor.ErrCodeBadRequestException)
+
+	resp, err := http.Post(server.URL+"/channel/test/schedule", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || resp.Header.Get("X-Amzn-Errortype") != "UnknownOperationException" {
+		t.Errorf("expected an unsupported operation to be rejected, got %s %s", resp.Status, resp.Header.Get("X-Amzn-Errortype"))
+	}
+}
+
+func TestEmulatorJSONProtocol(t *testing.T) {
+	output := &mediatailor.DescribeChannelOutput{
+		ChannelName:  aws.String("test"),
+		CreationTime: aws.Time(time.Unix(1700000000, 0).UTC()),
+		Outputs:      []*mediatailor.ResponseOutputItem{{ManifestName: aws.String("default"), SourceGroup: aws.String("default")}},
+		Tags:         map[string]*string{"Environment": aws.String("dev")},
+	}
+	body, err := jsonutil.BuildJSON(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"ChannelName":"test","CreationTime":1700000000,"Outputs":[{"ManifestName":"default","SourceGroup":"default"}],"tags":{"Environment":"dev"}}`
+	if string(body) != expected {
+		t.Errorf("expected the JSON %s, got %s", expected, body)
+	}
+
+	var decoded mediatailor.DescribeChannelOutput
+	if err := jsonutil.UnmarshalJSON(&decoded, bytes.NewReader(body)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, output) {
+		t.Errorf("expected the JSON to be decoded into %v, got %v", output, decoded)
+	}
+}
+
+// emulatorProvider returns the configuration of a provider that sends its requests to the emulator at the URL.
+func emulatorProvider(url string) string {
+	return fmt.Sprintf(`provider "awsmt" {
+							endpoint = "%[1]s"
+						}
+						`, url)
+}
+
+func TestEmulatorChannelLifecycle(t *testing.T) {
+	server, client := newEmulatorClient(t)
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			_, err := client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: aws.String("test")})
+			if !isNotFound(err) {
+				return fmt.Errorf("expected the channel to be deleted, got %v", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: emulatorProvider(server.URL) + offlineChannel("RUNNING", 60, map[string]string{"Environment": "dev"}),
+			},
+			{
+				Config: emulatorProvider(server.URL) + offlineChannel("RUNNING", 120, nil),
+				Check: func(*terraform.State) error {
+					described, err := client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: aws.String("test")})
+					if err != nil {
+						return err
+					}
+					if got := *outputNamed(described.Outputs, "default").DashPlaylistSettings.ManifestWindowSeconds; got != 120 || *described.ChannelState != mediatailor.ChannelStateRunning {
+						return fmt.Errorf("expected a running channel with a manifest window of 120, got %s and %d", *described.ChannelState, got)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,1108 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// @ADR
+// Context: Testing the resource lifecycle required AWS credentials and real MediaTailor resources, which made the
+// tests slow, costly and impossible to run offline.
+// Decision: We decided to implement mediaTailorClient with an in-memory backend that enforces the validation rules
+// and state transitions the provider relies on: channels must be stopped before they are updated or deleted, source
+// locations cannot be deleted while they contain sources, and tags are addressed by ARN.
+// Consequences: The fake only models the behaviour the provider uses. New SDK calls need a fake implementation, and
+// behaviour that diverges from AWS has to be fixed here as soon as it is noticed.
+
+const fakeErrCodeNotFound = "NotFoundException"
+
+// fakeMediaTailor is an in-memory implementation of mediaTailorClient. It is safe for concurrent use.
+type fakeMediaTailor struct {
+	region    string
+	accountID string
+	now       func() time.Time
+
+	mu                     sync.Mutex
+	requests               int
+	channels               map[string]*mediatailor.Channel
+	channelPolicies        map[string]string
+	programs               map[string]map[string]*mediatailor.DescribeProgramOutput
+	sourceLocations        map[string]*mediatailor.SourceLocation
+	vodSources             map[string]map[string]*mediatailor.VodSource
+	liveSources            map[string]map[string]*mediatailor.LiveSource
+	playbackConfigurations map[string]*mediatailor.PlaybackConfiguration
+}
+
+var _ mediaTailorClient = (*fakeMediaTailor)(nil)
+
+func newFakeMediaTailor(region, accountID string) *fakeMediaTailor {
+	return &fakeMediaTailor{
+		region:                 region,
+		accountID:              accountID,
+		now:                    time.Now,
+		channels:               map[string]*mediatailor.Channel{},
+		channelPolicies:        map[string]string{},
+		programs:               map[string]map[string]*mediatailor.DescribeProgramOutput{},
+		sourceLocations:        map[string]*mediatailor.SourceLocation{},
+		vodSources:             map[string]map[string]*mediatailor.VodSource{},
+		liveSources:            map[string]map[string]*mediatailor.LiveSource{},
+		playbackConfigurations: map[string]*mediatailor.PlaybackConfiguration{},
+	}
+}
+
+// begin validates the input the way the SDK does before sending a request, and locks the backend. The caller must
+// unlock it when the call returns without an error.
+func (f *fakeMediaTailor) begin(ctx aws.Context, input request.Validator) error {
+	if err := ctx.Err(); err != nil {
+		return awserr.New(request.CanceledErrorCode, "request context canceled", err)
+	}
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.requests++
+	return nil
+}
+
+func (f *fakeMediaTailor) requestID() string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", f.requests)
+}
+
+func (f *fakeMediaTailor) badRequest(format string, args ...interface{}) error {
+	return awserr.NewRequestFailure(awserr.New(mediatailor.ErrCodeBadRequestException, fmt.Sprintf(format, args...), nil), http.StatusBadRequest, f.requestID())
+}
+
+func (f *fakeMediaTailor) notFound(format string, args ...interface{}) error {
+	return awserr.NewRequestFailure(awserr.New(fakeErrCodeNotFound, fmt.Sprintf(format, args...), nil), http.StatusNotFound, f.requestID())
+}
+
+func (f *fakeMediaTailor) arn(resourceType string, names ...string) *string {
+	value, err := buildMediaTailorArn(f.region, f.accountID, resourceType, names...)
+	if err != nil {
+		panic(err)
+	}
+	return aws.String(value)
+}
+
+// fakeEndpointID derives a stable, account-like endpoint identifier from a resource name, the way MediaTailor
+// prefixes playback configuration endpoints.
+func fakeEndpointID(name string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func copyTags(tags map[string]*string) map[string]*string {
+	if tags == nil {
+		return nil
+	}
+	copied := map[string]*string{}
+	for k, v := range tags {
+		copied[k] = aws.String(aws.StringValue(v))
+	}
+	return copied
+}
+
+// CHANNELS
+
+func (f *fakeMediaTailor) channelOutputs(channelName string, outputs []*mediatailor.RequestOutputItem) ([]*mediatailor.ResponseOutputItem, error) {
+	var items []*mediatailor.ResponseOutputItem
+	seen := map[string]bool{}
+	for _, output := range outputs {
+		name := aws.StringValue(output.ManifestName)
+		if seen[name] {
+			return nil, f.badRequest("Duplicate manifest name %s in channel %s", name, channelName)
+		}
+		seen[name] = true
+
+		item := &mediatailor.ResponseOutputItem{ManifestName: output.ManifestName, SourceGroup: output.SourceGroup}
+		extension := ""
+		switch {
+		case output.HlsPlaylistSettings != nil && output.DashPlaylistSettings == nil:
+			item.HlsPlaylistSettings = awsutil.CopyOf(output.HlsPlaylistSettings).(*mediatailor.HlsPlaylistSettings)
+			if item.HlsPlaylistSettings.ManifestWindowSeconds == nil {
+				item.HlsPlaylistSettings.ManifestWindowSeconds = aws.Int64(defaultManifestWindowSeconds)
+			}
+			if len(item.HlsPlaylistSettings.AdMarkupType) == 0 {
+				item.HlsPlaylistSettings.AdMarkupType = aws.StringSlice([]string{defaultAdMarkupType})
+			}
+			extension = "m3u8"
+		case output.DashPlaylistSettings != nil && output.HlsPlaylistSettings == nil:
+			settings := awsutil.CopyOf(output.DashPlaylistSettings).(*mediatailor.DashPlaylistSettings)
+			if settings.ManifestWindowSeconds == nil {
+				settings.ManifestWindowSeconds = aws.Int64(defaultManifestWindowSeconds)
+			}
+			if settings.MinBufferTimeSeconds == nil {
+				settings.MinBufferTimeSeconds = aws.Int64(defaultMinBufferTimeSeconds)
+			}
+			if settings.MinUpdatePeriodSeconds == nil {
+				settings.MinUpdatePeriodSeconds = aws.Int64(defaultMinUpdatePeriodSeconds)
+			}
+			if settings.SuggestedPresentationDelaySeconds == nil {
+				settings.SuggestedPresentationDelaySeconds = aws.Int64(defaultSuggestedPresentationDelaySeconds)
+			}
+			item.DashPlaylistSettings = settings
+			extension = "mpd"
+		default:
+			return nil, f.badRequest("Output %s must specify exactly one of HlsPlaylistSettings and DashPlaylistSettings", name)
+		}
+		item.PlaybackUrl = aws.String(fmt.Sprintf("https://channel-assembly.mediatailor.%s.amazonaws.com/v1/channel/%s/%s.%s", f.region, channelName, name, extension))
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (f *fakeMediaTailor) channel(name *string) (*mediatailor.Channel, error) {
+	channel, ok := f.channels[aws.StringValue(name)]
+	if !ok {
+		return nil, f.notFound("Channel %s not found", aws.StringValue(name))
+	}
+	return channel, nil
+}
+
+func (f *fakeMediaTailor) CreateChannelWithContext(ctx aws.Context, input *mediatailor.CreateChannelInput, _ ...request.Option) (*mediatailor.CreateChannelOutput, error) {
+	output := &mediatailor.CreateChannelOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	name := *input.ChannelName
+	if _, ok := f.channels[name]; ok {
+		return output, f.badRequest("Channel %s already exists", name)
+	}
+	tier := aws.StringValue(input.Tier)
+	if tier == "" {
+		tier = defaultChannelTier
+	}
+	if !contains(mediatailor.Tier_Values(), tier) {
+		return output, f.badRequest("Invalid tier %s", tier)
+	}
+	if !contains(mediatailor.PlaybackMode_Values(), *input.PlaybackMode) {
+		return output, f.badRequest("Invalid playback mode %s", *input.PlaybackMode)
+	}
+	if input.FillerSlate != nil && *input.PlaybackMode != mediatailor.PlaybackModeLinear {
+		return output, f.badRequest("Filler slates are only supported by channels with the %s playback mode", mediatailor.PlaybackModeLinear)
+	}
+	outputs, err := f.channelOutputs(name, input.Outputs)
+	if err != nil {
+		return output, err
+	}
+
+	now := f.now()
+	channel := &mediatailor.Channel{
+		Arn:              f.arn("channel", name),
+		ChannelName:      aws.String(name),
+		ChannelState:     aws.String(mediatailor.ChannelStateStopped),
+		CreationTime:     &now,
+		FillerSlate:      input.FillerSlate,
+		LastModifiedTime: &now,
+		LogConfiguration: &mediatailor.LogConfigurationForChannel{LogTypes: []*string{}},
+		Outputs:          outputs,
+		PlaybackMode:     input.PlaybackMode,
+		Tags:             copyTags(input.Tags),
+		Tier:             aws.String(tier),
+	}
+	f.channels[name] = awsutil.CopyOf(channel).(*mediatailor.Channel)
+
+	awsutil.Copy(output, channel)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) DescribeChannelWithContext(ctx aws.Context, input *mediatailor.DescribeChannelInput, _ ...request.Option) (*mediatailor.DescribeChannelOutput, error) {
+	output := &mediatailor.DescribeChannelOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	channel, err := f.channel(input.ChannelName)
+	if err != nil {
+		return output, err
+	}
+	awsutil.Copy(output, channel)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) UpdateChannelWithContext(ctx aws.Context, input *mediatailor.UpdateChannelInput, _ ...request.Option) (*mediatailor.UpdateChannelOutput, error) {
+	output := &mediatailor.UpdateChannelOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	channel, err := f.channel(input.ChannelName)
+	if err != nil {
+		return output, err
+	}
+	if *channel.ChannelState != mediatailor.ChannelStateStopped {
+		return output, f.badRequest("Channel %s must be stopped before it can be updated", *input.ChannelName)
+	}
+	if input.FillerSlate != nil && *channel.PlaybackMode != mediatailor.PlaybackModeLinear {
+		return output, f.badRequest("Filler slates are only supported by channels with the %s playback mode", mediatailor.PlaybackModeLinear)
+	}
+	outputs, err := f.channelOutputs(*input.ChannelName, input.Outputs)
+	if err != nil {
+		return output, err
+	}
+
+	now := f.now()
+	channel.FillerSlate = nil
+	if input.FillerSlate != nil {
+		channel.FillerSlate = awsutil.CopyOf(input.FillerSlate).(*mediatailor.SlateSource)
+	}
+	channel.Outputs = outputs
+	channel.LastModifiedTime = &now
+
+	awsutil.Copy(output, channel)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) DeleteChannelWithContext(ctx aws.Context, input *mediatailor.DeleteChannelInput, _ ...request.Option) (*mediatailor.DeleteChannelOutput, error) {
+	output := &mediatailor.DeleteChannelOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	channel, err := f.channel(input.ChannelName)
+	if err != nil {
+		return output, err
+	}
+	if *channel.ChannelState != mediatailor.ChannelStateStopped {
+		return output, f.badRequest("Channel %s must be stopped before it can be deleted", *input.ChannelName)
+	}
+	delete(f.channels, *input.ChannelName)
+	delete(f.channelPolicies, *input.ChannelName)
+	delete(f.programs, *input.ChannelName)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) StartChannelWithContext(ctx aws.Context, input *mediatailor.StartChannelInput, _ ...request.Option) (*mediatailor.StartChannelOutput, error) {
+	output := &mediatailor.StartChannelOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	channel, err := f.channel(input.ChannelName)
+	if err != nil {
+		return output, err
+	}
+	channel.ChannelState = aws.String(mediatailor.ChannelStateRunning)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) StopChannelWithContext(ctx aws.Context, input *mediatailor.StopChannelInput, _ ...request.Option) (*mediatailor.StopChannelOutput, error) {
+	output := &mediatailor.StopChannelOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	channel, err := f.channel(input.ChannelName)
+	if err != nil {
+		return output, err
+	}
+	channel.ChannelState = aws.String(mediatailor.ChannelStateStopped)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) ListChannelsPagesWithContext(ctx aws.Context, input *mediatailor.ListChannelsInput, fn func(*mediatailor.ListChannelsOutput, bool) bool, _ ...request.Option) error {
+	if err := f.begin(ctx, input); err != nil {
+		return err
+	}
+	page := &mediatailor.ListChannelsOutput{}
+	for _, name := range sortedKeys(f.channels) {
+		page.Items = append(page.Items, awsutil.CopyOf(f.channels[name]).(*mediatailor.Channel))
+	}
+	f.mu.Unlock()
+
+	fn(page, true)
+	return nil
+}
+
+func (f *fakeMediaTailor) GetChannelPolicyWithContext(ctx aws.Context, input *mediatailor.GetChannelPolicyInput, _ ...request.Option) (*mediatailor.GetChannelPolicyOutput, error) {
+	output := &mediatailor.GetChannelPolicyOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	if _, err := f.channel(input.ChannelName); err != nil {
+		return output, err
+	}
+	policy, ok := f.channelPolicies[*input.ChannelName]
+	if !ok {
+		return output, f.notFound("Channel policy for channel %s not found", *input.ChannelName)
+	}
+	output.Policy = aws.String(policy)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) PutChannelPolicyWithContext(ctx aws.Context, input *mediatailor.PutChannelPolicyInput, _ ...request.Option) (*mediatailor.PutChannelPolicyOutput, error) {
+	output := &mediatailor.PutChannelPolicyOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	if _, err := f.channel(input.ChannelName); err != nil {
+		return output, err
+	}
+	if _, err := canonicalIamPolicy(*input.Policy); err != nil {
+		return output, f.badRequest("Invalid channel policy: %s", err)
+	}
+	f.channelPolicies[*input.ChannelName] = *input.Policy
+	return output, nil
+}
+
+// DeleteChannelPolicyWithContext succeeds for channels without a policy, as the provider deletes the policy of every
+// channel it destroys.
+func (f *fakeMediaTailor) DeleteChannelPolicyWithContext(ctx aws.Context, input *mediatailor.DeleteChannelPolicyInput, _ ...request.Option) (*mediatailor.DeleteChannelPolicyOutput, error) {
+	output := &mediatailor.DeleteChannelPolicyOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	if _, err := f.channel(input.ChannelName); err != nil {
+		return output, err
+	}
+	delete(f.channelPolicies, *input.ChannelName)
+	return output, nil
+}
+
+// PROGRAMS
+
+func (f *fakeMediaTailor) program(channelName, name *string) (*mediatailor.DescribeProgramOutput, error) {
+	if _, err := f.channel(channelName); err != nil {
+		return nil, err
+	}
+	program, ok := f.programs[*channelName][aws.StringValue(name)]
+	if !ok {
+		return nil, f.notFound("Program %s not found in channel %s", aws.StringValue(name), *channelName)
+	}
+	return program, nil
+}
+
+// CreateProgramWithContext schedules a program of a VOD or live source on a channel. The provider does not manage
+// programs, but the emulator serves this operation so that tests can prepare programs for the program data source.
+func (f *fakeMediaTailor) CreateProgramWithContext(ctx aws.Context, input *mediatailor.CreateProgramInput, _ ...request.Option) (*mediatailor.CreateProgramOutput, error) {
+	output := &mediatailor.CreateProgramOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	if _, err := f.channel(input.ChannelName); err != nil {
+		return output, err
+	}
+	if _, ok := f.programs[*input.ChannelName][*input.ProgramName]; ok {
+		return output, f.badRequest("Program %s already exists in channel %s", *input.ProgramName, *input.ChannelName)
+	}
+	switch {
+	case input.VodSourceName != nil && input.LiveSourceName == nil:
+		if _, err := f.vodSource(input.SourceLocationName, input.VodSourceName); err != nil {
+			return output, err
+		}
+	case input.LiveSourceName != nil && input.VodSourceName == nil:
+		if _, err := f.liveSource(input.SourceLocationName, input.LiveSourceName); err != nil {
+			return output, err
+		}
+	default:
+		return output, f.badRequest("Program %s must specify exactly one of VodSourceName and LiveSourceName", *input.ProgramName)
+	}
+
+	now := f.now()
+	start := now
+	if transition := input.ScheduleConfiguration.Transition; aws.StringValue(transition.Type) == "ABSOLUTE" && transition.ScheduledStartTimeMillis != nil {
+		start = time.UnixMilli(*transition.ScheduledStartTimeMillis)
+	}
+	program := &mediatailor.DescribeProgramOutput{}
+	awsutil.Copy(program, input)
+	program.Arn = aws.String(fmt.Sprintf("arn:aws:mediatailor:%s:%s:program/%s/%s", f.region, f.accountID, *input.ChannelName, *input.ProgramName))
+	program.ClipRange = input.ScheduleConfiguration.ClipRange
+	program.CreationTime = &now
+	program.ScheduledStartTime = &start
+	if f.programs[*input.ChannelName] == nil {
+		f.programs[*input.ChannelName] = map[string]*mediatailor.DescribeProgramOutput{}
+	}
+	f.programs[*input.ChannelName][*input.ProgramName] = program
+
+	awsutil.Copy(output, program)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) DescribeProgramWithContext(ctx aws.Context, input *mediatailor.DescribeProgramInput, _ ...request.Option) (*mediatailor.DescribeProgramOutput, error) {
+	output := &mediatailor.DescribeProgramOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	program, err := f.program(input.ChannelName, input.ProgramName)
+	if err != nil {
+		return output, err
+	}
+	awsutil.Copy(output, program)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) DeleteProgramWithContext(ctx aws.Context, input *mediatailor.DeleteProgramInput, _ ...request.Option) (*mediatailor.DeleteProgramOutput, error) {
+	output := &mediatailor.DeleteProgramOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	if _, err := f.program(input.ChannelName, input.ProgramName); err != nil {
+		return output, err
+	}
+	delete(f.programs[*input.ChannelName], *input.ProgramName)
+	return output, nil
+}
+
+// SOURCE LOCATIONS
+
+func (f *fakeMediaTailor) sourceLocation(name *string) (*mediatailor.SourceLocation, error) {
+	sourceLocation, ok := f.sourceLocations[aws.StringValue(name)]
+	if !ok {
+		return nil, f.notFound("Source location %s not found", aws.StringValue(name))
+	}
+	return sourceLocation, nil
+}
+
+func (f *fakeMediaTailor) validateSourceLocation(httpConfiguration *mediatailor.HttpConfiguration, segmentDeliveryConfigurations []*mediatailor.SegmentDeliveryConfiguration) error {
+	if !strings.HasPrefix(*httpConfiguration.BaseUrl, "http://") && !strings.HasPrefix(*httpConfiguration.BaseUrl, "https://") {
+		return f.badRequest("Invalid base URL %s", *httpConfiguration.BaseUrl)
+	}
+	seen := map[string]bool{}
+	for _, configuration := range segmentDeliveryConfigurations {
+		name := aws.StringValue(configuration.Name)
+		if seen[name] {
+			return f.badRequest("Duplicate segment delivery configuration %s", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+func (f *fakeMediaTailor) CreateSourceLocationWithContext(ctx aws.Context, input *mediatailor.CreateSourceLocationInput, _ ...request.Option) (*mediatailor.CreateSourceLocationOutput, error) {
+	output := &mediatailor.CreateSourceLocationOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	name := *input.SourceLocationName
+	if _, ok := f.sourceLocations[name]; ok {
+		return output, f.badRequest("Source location %s already exists", name)
+	}
+	if err := f.validateSourceLocation(input.HttpConfiguration, input.SegmentDeliveryConfigurations); err != nil {
+		return output, err
+	}
+
+	now := f.now()
+	sourceLocation := &mediatailor.SourceLocation{}
+	awsutil.Copy(sourceLocation, input)
+	sourceLocation.Arn = f.arn("sourceLocation", name)
+	sourceLocation.CreationTime = &now
+	sourceLocation.LastModifiedTime = &now
+	f.sourceLocations[name] = sourceLocation
+
+	awsutil.Copy(output, sourceLocation)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) DescribeSourceLocationWithContext(ctx aws.Context, input *mediatailor.DescribeSourceLocationInput, _ ...request.Option) (*mediatailor.DescribeSourceLocationOutput, error) {
+	output := &mediatailor.DescribeSourceLocationOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	sourceLocation, err := f.sourceLocation(input.SourceLocationName)
+	if err != nil {
+		return output, err
+	}
+	awsutil.Copy(output, sourceLocation)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) UpdateSourceLocationWithContext(ctx aws.Context, input *mediatailor.UpdateSourceLocationInput, _ ...request.Option) (*mediatailor.UpdateSourceLocationOutput, error) {
+	output := &mediatailor.UpdateSourceLocationOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	sourceLocation, err := f.sourceLocation(input.SourceLocationName)
+	if err != nil {
+		return output, err
+	}
+	if err := f.validateSourceLocation(input.HttpConfiguration, input.SegmentDeliveryConfigurations); err != nil {
+		return output, err
+	}
+
+	now := f.now()
+	updated := &mediatailor.SourceLocation{}
+	awsutil.Copy(updated, input)
+	updated.Arn = sourceLocation.Arn
+	updated.CreationTime = sourceLocation.CreationTime
+	updated.LastModifiedTime = &now
+	updated.Tags = sourceLocation.Tags
+	f.sourceLocations[*input.SourceLocationName] = updated
+
+	awsutil.Copy(output, updated)
+	return output, nil
+}
+
+// DeleteSourceLocationWithContext fails while the source location contains VOD or live sources, so callers have to
+// delete them first.
+func (f *fakeMediaTailor) DeleteSourceLocationWithContext(ctx aws.Context, input *mediatailor.DeleteSourceLocationInput, _ ...request.Option) (*mediatailor.DeleteSourceLocationOutput, error) {
+	output := &mediatailor.DeleteSourceLocationOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	name := *input.SourceLocationName
+	if _, err := f.sourceLocation(input.SourceLocationName); err != nil {
+		return output, err
+	}
+	if len(f.vodSources[name]) > 0 || len(f.liveSources[name]) > 0 {
+		return output, f.badRequest("Source location %s cannot be deleted while it contains VOD or live sources", name)
+	}
+	delete(f.sourceLocations, name)
+	delete(f.vodSources, name)
+	delete(f.liveSources, name)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) ListSourceLocationsPagesWithContext(ctx aws.Context, input *mediatailor.ListSourceLocationsInput, fn func(*mediatailor.ListSourceLocationsOutput, bool) bool, _ ...request.Option) error {
+	if err := f.begin(ctx, input); err != nil {
+		return err
+	}
+	page := &mediatailor.ListSourceLocationsOutput{}
+	for _, name := range sortedKeys(f.sourceLocations) {
+		page.Items = append(page.Items, awsutil.CopyOf(f.sourceLocations[name]).(*mediatailor.SourceLocation))
+	}
+	f.mu.Unlock()
+
+	fn(page, true)
+	return nil
+}
+
+// SOURCES
+
+func (f *fakeMediaTailor) validateHttpPackageConfigurations(configurations []*mediatailor.HttpPackageConfiguration) error {
+	seen := map[string]bool{}
+	for _, configuration := range configurations {
+		if !contains(mediatailor.Type_Values(), *configuration.Type) {
+			return f.badRequest("Invalid HTTP package configuration type %s", *configuration.Type)
+		}
+		key := *configuration.SourceGroup + "/" + *configuration.Type
+		if seen[key] {
+			return f.badRequest("Duplicate HTTP package configuration for source group %s and type %s", *configuration.SourceGroup, *configuration.Type)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func (f *fakeMediaTailor) vodSource(sourceLocationName, name *string) (*mediatailor.VodSource, error) {
+	if _, err := f.sourceLocation(sourceLocationName); err != nil {
+		return nil, err
+	}
+	vodSource, ok := f.vodSources[*sourceLocationName][aws.StringValue(name)]
+	if !ok {
+		return nil, f.notFound("VOD source %s not found in source location %s", aws.StringValue(name), *sourceLocationName)
+	}
+	return vodSource, nil
+}
+
+func (f *fakeMediaTailor) CreateVodSourceWithContext(ctx aws.Context, input *mediatailor.CreateVodSourceInput, _ ...request.Option) (*mediatailor.CreateVodSourceOutput, error) {
+	output := &mediatailor.CreateVodSourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	if _, err := f.sourceLocation(input.SourceLocationName); err != nil {
+		return output, err
+	}
+	if _, ok := f.vodSources[*input.SourceLocationName][*input.VodSourceName]; ok {
+		return output, f.badRequest("VOD source %s already exists in source location %s", *input.VodSourceName, *input.SourceLocationName)
+	}
+	if err := f.validateHttpPackageConfigurations(input.HttpPackageConfigurations); err != nil {
+		return output, err
+	}
+
+	now := f.now()
+	vodSource := &mediatailor.VodSource{}
+	awsutil.Copy(vodSource, input)
+	vodSource.Arn = f.arn("vodSource", *input.SourceLocationName, *input.VodSourceName)
+	vodSource.CreationTime = &now
+	vodSource.LastModifiedTime = &now
+	if f.vodSources[*input.SourceLocationName] == nil {
+		f.vodSources[*input.SourceLocationName] = map[string]*mediatailor.VodSource{}
+	}
+	f.vodSources[*input.SourceLocationName][*input.VodSourceName] = vodSource
+
+	awsutil.Copy(output, vodSource)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) DescribeVodSourceWithContext(ctx aws.Context, input *mediatailor.DescribeVodSourceInput, _ ...request.Option) (*mediatailor.DescribeVodSourceOutput, error) {
+	output := &mediatailor.DescribeVodSourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	vodSource, err := f.vodSource(input.SourceLocationName, input.VodSourceName)
+	if err != nil {
+		return output, err
+	}
+	awsutil.Copy(output, vodSource)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) UpdateVodSourceWithContext(ctx aws.Context, input *mediatailor.UpdateVodSourceInput, _ ...request.Option) (*mediatailor.UpdateVodSourceOutput, error) {
+	output := &mediatailor.UpdateVodSourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	vodSource, err := f.vodSource(input.SourceLocationName, input.VodSourceName)
+	if err != nil {
+		return output, err
+	}
+	if err := f.validateHttpPackageConfigurations(input.HttpPackageConfigurations); err != nil {
+		return output, err
+	}
+
+	now := f.now()
+	awsutil.Copy(&vodSource.HttpPackageConfigurations, &input.HttpPackageConfigurations)
+	vodSource.LastModifiedTime = &now
+
+	awsutil.Copy(output, vodSource)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) DeleteVodSourceWithContext(ctx aws.Context, input *mediatailor.DeleteVodSourceInput, _ ...request.Option) (*mediatailor.DeleteVodSourceOutput, error) {
+	output := &mediatailor.DeleteVodSourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	if _, err := f.vodSource(input.SourceLocationName, input.VodSourceName); err != nil {
+		return output, err
+	}
+	delete(f.vodSources[*input.SourceLocationName], *input.VodSourceName)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) listVodSources(sourceLocationName *string) (*mediatailor.ListVodSourcesOutput, error) {
+	if _, err := f.sourceLocation(sourceLocationName); err != nil {
+		return &mediatailor.ListVodSourcesOutput{}, err
+	}
+	output := &mediatailor.ListVodSourcesOutput{}
+	vodSources := f.vodSources[*sourceLocationName]
+	for _, name := range sortedKeys(vodSources) {
+		output.Items = append(output.Items, awsutil.CopyOf(vodSources[name]).(*mediatailor.VodSource))
+	}
+	return output, nil
+}
+
+func (f *fakeMediaTailor) ListVodSourcesWithContext(ctx aws.Context, input *mediatailor.ListVodSourcesInput, _ ...request.Option) (*mediatailor.ListVodSourcesOutput, error) {
+	if err := f.begin(ctx, input); err != nil {
+		return &mediatailor.ListVodSourcesOutput{}, err
+	}
+	defer f.mu.Unlock()
+
+	return f.listVodSources(input.SourceLocationName)
+}
+
+func (f *fakeMediaTailor) ListVodSourcesPagesWithContext(ctx aws.Context, input *mediatailor.ListVodSourcesInput, fn func(*mediatailor.ListVodSourcesOutput, bool) bool, _ ...request.Option) error {
+	if err := f.begin(ctx, input); err != nil {
+		return err
+	}
+	page, err := f.listVodSources(input.SourceLocationName)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	fn(page, true)
+	return nil
+}
+
+func (f *fakeMediaTailor) liveSource(sourceLocationName, name *string) (*mediatailor.LiveSource, error) {
+	if _, err := f.sourceLocation(sourceLocationName); err != nil {
+		return nil, err
+	}
+	liveSource, ok := f.liveSources[*sourceLocationName][aws.StringValue(name)]
+	if !ok {
+		return nil, f.notFound("Live source %s not found in source location %s", aws.StringValue(name), *sourceLocationName)
+	}
+	return liveSource, nil
+}
+
+func (f *fakeMediaTailor) CreateLiveSourceWithContext(ctx aws.Context, input *mediatailor.CreateLiveSourceInput, _ ...request.Option) (*mediatailor.CreateLiveSourceOutput, error) {
+	output := &mediatailor.CreateLiveSourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	if _, err := f.sourceLocation(input.SourceLocationName); err != nil {
+		return output, err
+	}
+	if _, ok := f.liveSources[*input.SourceLocationName][*input.LiveSourceName]; ok {
+		return output, f.badRequest("Live source %s already exists in source location %s", *input.LiveSourceName, *input.SourceLocationName)
+	}
+	if err := f.validateHttpPackageConfigurations(input.HttpPackageConfigurations); err != nil {
+		return output, err
+	}
+
+	now := f.now()
+	liveSource := &mediatailor.LiveSource{}
+	awsutil.Copy(liveSource, input)
+	liveSource.Arn = f.arn("liveSource", *input.SourceLocationName, *input.LiveSourceName)
+	liveSource.CreationTime = &now
+	liveSource.LastModifiedTime = &now
+	if f.liveSources[*input.SourceLocationName] == nil {
+		f.liveSources[*input.SourceLocationName] = map[string]*mediatailor.LiveSource{}
+	}
+	f.liveSources[*input.SourceLocationName][*input.LiveSourceName] = liveSource
+
+	awsutil.Copy(output, liveSource)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) DescribeLiveSourceWithContext(ctx aws.Context, input *mediatailor.DescribeLiveSourceInput, _ ...request.Option) (*mediatailor.DescribeLiveSourceOutput, error) {
+	output := &mediatailor.DescribeLiveSourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	liveSource, err := f.liveSource(input.SourceLocationName, input.LiveSourceName)
+	if err != nil {
+		return output, err
+	}
+	awsutil.Copy(output, liveSource)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) UpdateLiveSourceWithContext(ctx aws.Context, input *mediatailor.UpdateLiveSourceInput, _ ...request.Option) (*mediatailor.UpdateLiveSourceOutput, error) {
+	output := &mediatailor.UpdateLiveSourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	liveSource, err := f.liveSource(input.SourceLocationName, input.LiveSourceName)
+	if err != nil {
+		return output, err
+	}
+	if err := f.validateHttpPackageConfigurations(input.HttpPackageConfigurations); err != nil {
+		return output, err
+	}
+
+	now := f.now()
+	awsutil.Copy(&liveSource.HttpPackageConfigurations, &input.HttpPackageConfigurations)
+	liveSource.LastModifiedTime = &now
+
+	awsutil.Copy(output, liveSource)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) DeleteLiveSourceWithContext(ctx aws.Context, input *mediatailor.DeleteLiveSourceInput, _ ...request.Option) (*mediatailor.DeleteLiveSourceOutput, error) {
+	output := &mediatailor.DeleteLiveSourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	if _, err := f.liveSource(input.SourceLocationName, input.LiveSourceName); err != nil {
+		return output, err
+	}
+	delete(f.liveSources[*input.SourceLocationName], *input.LiveSourceName)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) listLiveSources(sourceLocationName *string) (*mediatailor.ListLiveSourcesOutput, error) {
+	if _, err := f.sourceLocation(sourceLocationName); err != nil {
+		return &mediatailor.ListLiveSourcesOutput{}, err
+	}
+	output := &mediatailor.ListLiveSourcesOutput{}
+	liveSources := f.liveSources[*sourceLocationName]
+	for _, name := range sortedKeys(liveSources) {
+		output.Items = append(output.Items, awsutil.CopyOf(liveSources[name]).(*mediatailor.LiveSource))
+	}
+	return output, nil
+}
+
+func (f *fakeMediaTailor) ListLiveSourcesWithContext(ctx aws.Context, input *mediatailor.ListLiveSourcesInput, _ ...request.Option) (*mediatailor.ListLiveSourcesOutput, error) {
+	if err := f.begin(ctx, input); err != nil {
+		return &mediatailor.ListLiveSourcesOutput{}, err
+	}
+	defer f.mu.Unlock()
+
+	return f.listLiveSources(input.SourceLocationName)
+}
+
+func (f *fakeMediaTailor) ListLiveSourcesPagesWithContext(ctx aws.Context, input *mediatailor.ListLiveSourcesInput, fn func(*mediatailor.ListLiveSourcesOutput, bool) bool, _ ...request.Option) error {
+	if err := f.begin(ctx, input); err != nil {
+		return err
+	}
+	page, err := f.listLiveSources(input.SourceLocationName)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	fn(page, true)
+	return nil
+}
+
+// PLAYBACK CONFIGURATIONS
+
+// PutPlaybackConfigurationWithContext creates the playback configuration, or replaces every setting of an existing
+// one. Tags are added to the existing tags, as with TagResource.
+func (f *fakeMediaTailor) PutPlaybackConfigurationWithContext(ctx aws.Context, input *mediatailor.PutPlaybackConfigurationInput, _ ...request.Option) (*mediatailor.PutPlaybackConfigurationOutput, error) {
+	output := &mediatailor.PutPlaybackConfigurationOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	name := *input.Name
+	dashConfiguration := &mediatailor.DashConfiguration{
+		MpdLocation:        aws.String("EMT_DEFAULT"),
+		OriginManifestType: aws.String(mediatailor.OriginManifestTypeMultiPeriod),
+	}
+	if input.DashConfiguration != nil {
+		if input.DashConfiguration.MpdLocation != nil {
+			dashConfiguration.MpdLocation = input.DashConfiguration.MpdLocation
+		}
+		if input.DashConfiguration.OriginManifestType != nil {
+			if !contains(mediatailor.OriginManifestType_Values(), *input.DashConfiguration.OriginManifestType) {
+				return output, f.badRequest("Invalid origin manifest type %s", *input.DashConfiguration.OriginManifestType)
+			}
+			dashConfiguration.OriginManifestType = input.DashConfiguration.OriginManifestType
+		}
+	}
+
+	host := fmt.Sprintf("https://%s.mediatailor.%s.amazonaws.com", fakeEndpointID(name), f.region)
+	dashConfiguration.ManifestEndpointPrefix = aws.String(fmt.Sprintf("%s/v1/dash/%s/%s/", host, f.accountID, name))
+
+	// DashConfigurationForPut cannot be copied into a DashConfiguration, which was built above.
+	settings := *input
+	settings.DashConfiguration = nil
+	playbackConfiguration := &mediatailor.PlaybackConfiguration{}
+	awsutil.Copy(playbackConfiguration, &settings)
+	playbackConfiguration.DashConfiguration = dashConfiguration
+	playbackConfiguration.HlsConfiguration = &mediatailor.HlsConfiguration{
+		ManifestEndpointPrefix: aws.String(fmt.Sprintf("%s/v1/master/%s/%s/", host, f.accountID, name)),
+	}
+	playbackConfiguration.LogConfiguration = &mediatailor.LogConfiguration{PercentEnabled: aws.Int64(0)}
+	playbackConfiguration.PlaybackConfigurationArn = f.arn("playbackConfiguration", name)
+	playbackConfiguration.PlaybackEndpointPrefix = aws.String(host)
+	playbackConfiguration.SessionInitializationEndpointPrefix = aws.String(fmt.Sprintf("%s/v1/session/%s/%s/", host, f.accountID, name))
+
+	tags := map[string]*string{}
+	if existing, ok := f.playbackConfigurations[name]; ok {
+		for k, v := range existing.Tags {
+			tags[k] = v
+		}
+	}
+	for k, v := range copyTags(input.Tags) {
+		tags[k] = v
+	}
+	playbackConfiguration.Tags = nil
+	if len(tags) > 0 {
+		playbackConfiguration.Tags = tags
+	}
+	f.playbackConfigurations[name] = playbackConfiguration
+
+	awsutil.Copy(output, playbackConfiguration)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) GetPlaybackConfigurationWithContext(ctx aws.Context, input *mediatailor.GetPlaybackConfigurationInput, _ ...request.Option) (*mediatailor.GetPlaybackConfigurationOutput, error) {
+	output := &mediatailor.GetPlaybackConfigurationOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	playbackConfiguration, ok := f.playbackConfigurations[*input.Name]
+	if !ok {
+		return output, f.notFound("Playback configuration %s not found", *input.Name)
+	}
+	awsutil.Copy(output, playbackConfiguration)
+	return output, nil
+}
+
+// DeletePlaybackConfigurationWithContext succeeds for playback configurations that do not exist.
+func (f *fakeMediaTailor) DeletePlaybackConfigurationWithContext(ctx aws.Context, input *mediatailor.DeletePlaybackConfigurationInput, _ ...request.Option) (*mediatailor.DeletePlaybackConfigurationOutput, error) {
+	output := &mediatailor.DeletePlaybackConfigurationOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	delete(f.playbackConfigurations, *input.Name)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) ListPlaybackConfigurationsPagesWithContext(ctx aws.Context, input *mediatailor.ListPlaybackConfigurationsInput, fn func(*mediatailor.ListPlaybackConfigurationsOutput, bool) bool, _ ...request.Option) error {
+	if err := f.begin(ctx, input); err != nil {
+		return err
+	}
+	page := &mediatailor.ListPlaybackConfigurationsOutput{}
+	for _, name := range sortedKeys(f.playbackConfigurations) {
+		page.Items = append(page.Items, awsutil.CopyOf(f.playbackConfigurations[name]).(*mediatailor.PlaybackConfiguration))
+	}
+	f.mu.Unlock()
+
+	fn(page, true)
+	return nil
+}
+
+func (f *fakeMediaTailor) ListPrefetchSchedulesPagesWithContext(ctx aws.Context, input *mediatailor.ListPrefetchSchedulesInput, fn func(*mediatailor.ListPrefetchSchedulesOutput, bool) bool, _ ...request.Option) error {
+	if err := f.begin(ctx, input); err != nil {
+		return err
+	}
+	if _, ok := f.playbackConfigurations[*input.PlaybackConfigurationName]; !ok {
+		defer f.mu.Unlock()
+		return f.notFound("Playback configuration %s not found", *input.PlaybackConfigurationName)
+	}
+	f.mu.Unlock()
+
+	fn(&mediatailor.ListPrefetchSchedulesOutput{}, true)
+	return nil
+}
+
+// TAGS
+
+// tags returns the tags of the resource with the given ARN.
+func (f *fakeMediaTailor) tags(resourceArn string) (*map[string]*string, error) {
+	parsed, err := parseMediaTailorArn(resourceArn)
+	if err != nil {
+		return nil, f.badRequest("Invalid resource ARN %s: %s", resourceArn, err)
+	}
+	names := parsed.Names
+	switch parsed.ResourceType {
+	case "channel":
+		if channel, ok := f.channels[names[0]]; ok {
+			return &channel.Tags, nil
+		}
+	case "playbackConfiguration":
+		if playbackConfiguration, ok := f.playbackConfigurations[names[0]]; ok {
+			return &playbackConfiguration.Tags, nil
+		}
+	case "sourceLocation":
+		if sourceLocation, ok := f.sourceLocations[names[0]]; ok {
+			return &sourceLocation.Tags, nil
+		}
+	case "vodSource":
+		if vodSource, ok := f.vodSources[names[0]][names[1]]; ok {
+			return &vodSource.Tags, nil
+		}
+	case "liveSource":
+		if liveSource, ok := f.liveSources[names[0]][names[1]]; ok {
+			return &liveSource.Tags, nil
+		}
+	}
+	return nil, f.notFound("Resource %s not found", resourceArn)
+}
+
+func (f *fakeMediaTailor) ListTagsForResourceWithContext(ctx aws.Context, input *mediatailor.ListTagsForResourceInput, _ ...request.Option) (*mediatailor.ListTagsForResourceOutput, error) {
+	output := &mediatailor.ListTagsForResourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	tags, err := f.tags(*input.ResourceArn)
+	if err != nil {
+		return output, err
+	}
+	output.Tags = copyTags(*tags)
+	return output, nil
+}
+
+func (f *fakeMediaTailor) TagResourceWithContext(ctx aws.Context, input *mediatailor.TagResourceInput, _ ...request.Option) (*mediatailor.TagResourceOutput, error) {
+	output := &mediatailor.TagResourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	tags, err := f.tags(*input.ResourceArn)
+	if err != nil {
+		return output, err
+	}
+	if *tags == nil {
+		*tags = map[string]*string{}
+	}
+	for k, v := range copyTags(input.Tags) {
+		(*tags)[k] = v
+	}
+	return output, nil
+}
+
+func (f *fakeMediaTailor) UntagResourceWithContext(ctx aws.Context, input *mediatailor.UntagResourceInput, _ ...request.Option) (*mediatailor.UntagResourceOutput, error) {
+	output := &mediatailor.UntagResourceOutput{}
+	if err := f.begin(ctx, input); err != nil {
+		return output, err
+	}
+	defer f.mu.Unlock()
+
+	tags, err := f.tags(*input.ResourceArn)
+	if err != nil {
+		return output, err
+	}
+	for _, key := range input.TagKeys {
+		delete(*tags, aws.StringValue(key))
+	}
+	if len(*tags) == 0 {
+		*tags = nil
+	}
+	return output, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,157 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"testing"
+)
+
+func fakeChannelInput(name string) *mediatailor.CreateChannelInput {
+	return &mediatailor.CreateChannelInput{
+		ChannelName: aws.String(name),
+		Outputs: []*mediatailor.RequestOutputItem{{
+			ManifestName:        aws.String("default"),
+			SourceGroup:         aws.String("default"),
+			HlsPlaylistSettings: &mediatailor.HlsPlaylistSettings{},
+		}},
+		PlaybackMode: aws.String(mediatailor.PlaybackModeLoop),
+	}
+}
+
+func requireErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		t.Fatalf("expected an %s error, got %v", code, err)
+	}
+	if awsErr.Code() != code {
+		t.Fatalf("expected an %s error, got %s: %s", code, awsErr.Code(), awsErr.Message())
+	}
+}
+
+func TestFakeMediaTailorChannelStates(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeMediaTailor("eu-central-1", "123456789012")
+
+	created, err := fake.CreateChannelWithContext(ctx, fakeChannelInput("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *created.ChannelState != mediatailor.ChannelStateStopped || *created.Tier != defaultChannelTier {
+		t.Errorf("expected a stopped %s channel, got a %s %s channel", defaultChannelTier, *created.ChannelState, *created.Tier)
+	}
+	if got := *created.Outputs[0].PlaybackUrl; got != "https://channel-assembly.mediatailor.eu-central-1.amazonaws.com/v1/channel/test/default.m3u8" {
+		t.Errorf("unexpected playback URL %s", got)
+	}
+
+	_, err = fake.CreateChannelWithContext(ctx, fakeChannelInput("test"))
+	requireErrorCode(t, err, mediatailor.ErrCodeBadRequestException)
+
+	if _, err := fake.StartChannelWithContext(ctx, &mediatailor.StartChannelInput{ChannelName: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = fake.UpdateChannelWithContext(ctx, &mediatailor.UpdateChannelInput{ChannelName: aws.String("test"), Outputs: fakeChannelInput("test").Outputs})
+	requireErrorCode(t, err, mediatailor.ErrCodeBadRequestException)
+	_, err = fake.DeleteChannelWithContext(ctx, &mediatailor.DeleteChannelInput{ChannelName: aws.String("test")})
+	requireErrorCode(t, err, mediatailor.ErrCodeBadRequestException)
+
+	if _, err := fake.StopChannelWithContext(ctx, &mediatailor.StopChannelInput{ChannelName: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fake.PutChannelPolicyWithContext(ctx, &mediatailor.PutChannelPolicyInput{ChannelName: aws.String("test"), Policy: aws.String(`{"Version": "2012-10-17", "Statement": []}`)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fake.DeleteChannelWithContext(ctx, &mediatailor.DeleteChannelInput{ChannelName: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := fake.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: aws.String("test")})
+	requireErrorCode(t, err, fakeErrCodeNotFound)
+	if output == nil {
+		t.Error("expected an empty output alongside the error, as returned by the SDK")
+	}
+	if len(fake.channelPolicies) != 0 {
+		t.Error("expected the channel policy to be deleted with the channel")
+	}
+}
+
+func TestFakeMediaTailorSourceLocationWithSources(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeMediaTailor("eu-central-1", "123456789012")
+
+	_, err := fake.CreateVodSourceWithContext(ctx, &mediatailor.CreateVodSourceInput{
+		SourceLocationName:        aws.String("location"),
+		VodSourceName:             aws.String("vod"),
+		HttpPackageConfigurations: []*mediatailor.HttpPackageConfiguration{{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: aws.String("HLS")}},
+	})
+	requireErrorCode(t, err, fakeErrCodeNotFound)
+
+	if _, err := fake.CreateSourceLocationWithContext(ctx, &mediatailor.CreateSourceLocationInput{
+		SourceLocationName: aws.String("location"),
+		HttpConfiguration:  &mediatailor.HttpConfiguration{BaseUrl: aws.String("https://example.com")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fake.CreateVodSourceWithContext(ctx, &mediatailor.CreateVodSourceInput{
+		SourceLocationName:        aws.String("location"),
+		VodSourceName:             aws.String("vod"),
+		HttpPackageConfigurations: []*mediatailor.HttpPackageConfiguration{{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: aws.String("HLS")}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = fake.DeleteSourceLocationWithContext(ctx, &mediatailor.DeleteSourceLocationInput{SourceLocationName: aws.String("location")})
+	requireErrorCode(t, err, mediatailor.ErrCodeBadRequestException)
+
+	if err := deleteSourceLocation(ctx, fake, aws.String("location")); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.sourceLocations) != 0 || len(fake.vodSources) != 0 {
+		t.Error("expected the source location and its VOD source to be deleted")
+	}
+}
+
+func TestFakeMediaTailorTags(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeMediaTailor("eu-central-1", "123456789012")
+
+	input := fakeChannelInput("test")
+	input.Tags = map[string]*string{"Environment": aws.String("dev")}
+	created, err := fake.CreateChannelWithContext(ctx, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := updatesTags(ctx, fake, created.Tags, map[string]*string{"Team": aws.String("video")}, *created.Arn); err != nil {
+		t.Fatal(err)
+	}
+	tags := fake.channels["test"].Tags
+	if len(tags) != 1 || *tags["Team"] != "video" {
+		t.Errorf("unexpected tags %v", tags)
+	}
+
+	if err := updatesTags(ctx, fake, tags, nil, *created.Arn); err != nil {
+		t.Fatal(err)
+	}
+	if tags := fake.channels["test"].Tags; tags != nil {
+		t.Errorf("expected the tags to be removed, got %v", tags)
+	}
+
+	_, err = fake.TagResourceWithContext(ctx, &mediatailor.TagResourceInput{ResourceArn: aws.String("arn:aws:mediatailor:eu-central-1:123456789012:channel/missing"), Tags: input.Tags})
+	requireErrorCode(t, err, fakeErrCodeNotFound)
+}
+
+func TestFakeMediaTailorRequestValidation(t *testing.T) {
+	fake := newFakeMediaTailor("eu-central-1", "123456789012")
+
+	_, err := fake.TagResourceWithContext(context.Background(), &mediatailor.TagResourceInput{ResourceArn: aws.String("arn:aws:mediatailor:eu-central-1:123456789012:channel/test")})
+	requireErrorCode(t, err, request.InvalidParameterErrCode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = fake.CreateChannelWithContext(ctx, fakeChannelInput("test"))
+	requireErrorCode(t, err, request.CanceledErrorCode)
+}
@@ -0,0 +1,83 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = &functionBuildArn{}
+)
+
+func FunctionChannelArn() function.Function {
+	return &functionBuildArn{name: "channel_arn", resourceType: "channel", description: "channel"}
+}
+
+func FunctionPlaybackConfigurationArn() function.Function {
+	return &functionBuildArn{name: "playback_configuration_arn", resourceType: "playbackConfiguration", description: "playback configuration"}
+}
+
+func FunctionSourceLocationArn() function.Function {
+	return &functionBuildArn{name: "source_location_arn", resourceType: "sourceLocation", description: "source location"}
+}
+
+func FunctionVodSourceArn() function.Function {
+	return &functionBuildArn{name: "vod_source_arn", resourceType: "vodSource", description: "VOD source"}
+}
+
+func FunctionLiveSourceArn() function.Function {
+	return &functionBuildArn{name: "live_source_arn", resourceType: "liveSource", description: "live source"}
+}
+
+// functionBuildArn builds the ARN of a MediaTailor resource from its region, account ID and name(s). VOD and live
+// sources additionally take the name of their source location before their own name.
+type functionBuildArn struct {
+	name         string
+	resourceType string
+	description  string
+}
+
+func (f *functionBuildArn) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f *functionBuildArn) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	parameters := []function.Parameter{
+		function.StringParameter{Name: "region", Description: "The AWS region of the " + f.description + "."},
+		function.StringParameter{Name: "account", Description: "The 12 digit ID of the AWS account owning the " + f.description + "."},
+	}
+	if mediaTailorArnNameCount[f.resourceType] == 2 {
+		parameters = append(parameters, function.StringParameter{Name: "source_location_name", Description: "The name of the source location containing the " + f.description + "."})
+	}
+	parameters = append(parameters, function.StringParameter{Name: "name", Description: "The name of the " + f.description + "."})
+
+	resp.Definition = function.Definition{
+		Summary:     fmt.Sprintf("Builds the ARN of a MediaTailor %s.", f.description),
+		Description: fmt.Sprintf("Builds the ARN of a MediaTailor %s. The partition is derived from the region.", f.description),
+		Parameters:  parameters,
+		Return:      function.StringReturn{},
+	}
+}
+
+func (f *functionBuildArn) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var region, account string
+	names := make([]string, mediaTailorArnNameCount[f.resourceType])
+
+	targets := []any{&region, &account}
+	for i := range names {
+		targets = append(targets, &names[i])
+	}
+	resp.Error = req.Arguments.Get(ctx, targets...)
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := buildMediaTailorArn(region, account, f.resourceType, names...)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, value)
+}
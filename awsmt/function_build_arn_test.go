@@ -0,0 +1,51 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"testing"
+)
+
+func runFunction(f function.Function, arguments ...string) function.RunResponse {
+	values := make([]attr.Value, len(arguments))
+	for i, argument := range arguments {
+		values[i] = types.StringValue(argument)
+	}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	if _, ok := f.(*functionParseArn); ok {
+		resp.Result = function.NewResultData(types.ObjectUnknown(parsedArnAttributeTypes))
+	}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(values)}, &resp)
+	return resp
+}
+
+func TestFunctionBuildArn(t *testing.T) {
+	for _, c := range []struct {
+		function  function.Function
+		arguments []string
+		expected  string
+	}{
+		{FunctionChannelArn(), []string{"eu-central-1", "123456789012", "test"}, "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"},
+		{FunctionPlaybackConfigurationArn(), []string{"eu-central-1", "123456789012", "test"}, "arn:aws:mediatailor:eu-central-1:123456789012:playbackConfiguration/test"},
+		{FunctionSourceLocationArn(), []string{"eu-central-1", "123456789012", "test"}, "arn:aws:mediatailor:eu-central-1:123456789012:sourceLocation/test"},
+		{FunctionVodSourceArn(), []string{"eu-central-1", "123456789012", "location", "test"}, "arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location/test"},
+		{FunctionLiveSourceArn(), []string{"eu-central-1", "123456789012", "location", "test"}, "arn:aws:mediatailor:eu-central-1:123456789012:liveSource/location/test"},
+	} {
+		resp := runFunction(c.function, c.arguments...)
+		if resp.Error != nil {
+			t.Fatalf("unexpected error: %s", resp.Error)
+		}
+		if !resp.Result.Value().Equal(types.StringValue(c.expected)) {
+			t.Errorf("expected %q, got %s", c.expected, resp.Result.Value())
+		}
+	}
+}
+
+func TestFunctionBuildArnErrors(t *testing.T) {
+	resp := runFunction(FunctionChannelArn(), "eu-central-1", "not-an-account", "test")
+	if resp.Error == nil {
+		t.Error("expected an error for an invalid account ID")
+	}
+}
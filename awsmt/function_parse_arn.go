@@ -0,0 +1,79 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &functionParseArn{}
+)
+
+func FunctionParseArn() function.Function {
+	return &functionParseArn{}
+}
+
+type functionParseArn struct{}
+
+// parsedArnModel is the object returned by parse_arn. Names holds the source location name before the source name
+// for VOD and live sources, and a single name for every other resource type.
+type parsedArnModel struct {
+	Type      types.String `tfsdk:"type"`
+	Partition types.String `tfsdk:"partition"`
+	Region    types.String `tfsdk:"region"`
+	Account   types.String `tfsdk:"account"`
+	Names     types.List   `tfsdk:"names"`
+}
+
+var parsedArnAttributeTypes = map[string]attr.Type{
+	"type":      types.StringType,
+	"partition": types.StringType,
+	"region":    types.StringType,
+	"account":   types.StringType,
+	"names":     types.ListType{ElemType: types.StringType},
+}
+
+func (f *functionParseArn) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_arn"
+}
+
+func (f *functionParseArn) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parses the ARN of a MediaTailor resource.",
+		Description: "Parses the ARN of a MediaTailor channel, playback configuration, source location, VOD source or live source and returns its resource type, partition, region, account and names.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "arn", Description: "The ARN to parse."},
+		},
+		Return: function.ObjectReturn{AttributeTypes: parsedArnAttributeTypes},
+	}
+}
+
+func (f *functionParseArn) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseMediaTailorArn(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	names, diags := types.ListValueFrom(ctx, types.StringType, parsed.Names)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, parsedArnModel{
+		Type:      types.StringValue(parsed.ResourceType),
+		Partition: types.StringValue(parsed.Partition),
+		Region:    types.StringValue(parsed.Region),
+		Account:   types.StringValue(parsed.AccountID),
+		Names:     names,
+	})
+}
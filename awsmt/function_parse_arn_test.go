@@ -0,0 +1,39 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"reflect"
+	"testing"
+)
+
+func TestFunctionParseArn(t *testing.T) {
+	ctx := context.Background()
+	resp := runFunction(FunctionParseArn(), "arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location/test")
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	var parsed parsedArnModel
+	if diags := resp.Result.Value().(types.Object).As(ctx, &parsed, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	var names []string
+	parsed.Names.ElementsAs(ctx, &names, false)
+
+	if parsed.Type.ValueString() != "vodSource" || parsed.Partition.ValueString() != "aws" ||
+		parsed.Region.ValueString() != "eu-central-1" || parsed.Account.ValueString() != "123456789012" {
+		t.Errorf("unexpected result: %+v", parsed)
+	}
+	if !reflect.DeepEqual(names, []string{"location", "test"}) {
+		t.Errorf("expected names [location test], got %v", names)
+	}
+}
+
+func TestFunctionParseArnErrors(t *testing.T) {
+	resp := runFunction(FunctionParseArn(), "arn:aws:s3:::bucket")
+	if resp.Error == nil {
+		t.Error("expected an error for a non-MediaTailor ARN")
+	}
+}
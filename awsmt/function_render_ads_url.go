@@ -0,0 +1,56 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &functionRenderAdsUrl{}
+)
+
+func FunctionRenderAdsUrl() function.Function {
+	return &functionRenderAdsUrl{}
+}
+
+type functionRenderAdsUrl struct{}
+
+func (f *functionRenderAdsUrl) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "render_ads_url"
+}
+
+func (f *functionRenderAdsUrl) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Expands the dynamic variables of an ad decision server URL template.",
+		Description: "Expands the dynamic variables of an ad decision server URL template the way MediaTailor does for a session. Session and avail variables default to sample values; SCTE-35 and player_params variables must be given.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "template", Description: "The ad decision server URL template, e.g. the ad_decision_server_url of a playback configuration."},
+			function.MapParameter{Name: "values", ElementType: types.StringType, Description: "The values of the variables, keyed by their name without brackets, e.g. \"player_params.foo\"."},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionRenderAdsUrl) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var template string
+	var values map[string]string
+	resp.Error = req.Arguments.Get(ctx, &template, &values)
+	if resp.Error != nil {
+		return
+	}
+
+	rendered, err := renderAdsUrl(template, values)
+	var valuesErr adsUrlValuesError
+	if errors.As(err, &valuesErr) {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, rendered)
+}
@@ -0,0 +1,61 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"testing"
+)
+
+func runRenderAdsUrl(template string, values map[string]string) function.RunResponse {
+	elements := map[string]attr.Value{}
+	for key, value := range values {
+		elements[key] = types.StringValue(value)
+	}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	FunctionRenderAdsUrl().Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+		types.StringValue(template),
+		types.MapValueMust(types.StringType, elements),
+	})}, &resp)
+	return resp
+}
+
+func TestFunctionRenderAdsUrl(t *testing.T) {
+	resp := runRenderAdsUrl("https://ads.example.com/?n=[avail.index]&c=[player_params.c]", map[string]string{"player_params.c": "sports"})
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	if got := resp.Result.Value().(types.String).ValueString(); got != "https://ads.example.com/?n=1&c=sports" {
+		t.Errorf("unexpected result %q", got)
+	}
+}
+
+func TestFunctionRenderAdsUrlErrors(t *testing.T) {
+	for _, c := range []struct {
+		description string
+		template    string
+		values      map[string]string
+		argument    int64
+		message     string
+	}{
+		{"an unclosed bracket", "https://ads.example.com/?c=[player_params.c", nil, 0, "unclosed '['"},
+		{"an unknown namespace", "https://ads.example.com/?c=[player.c]", nil, 0, "unknown namespace"},
+		{"a missing value", "https://ads.example.com/?c=[player_params.c]&e=[scte.event_id]", nil, 1, "no value given for player_params.c, scte.event_id"},
+		{"a value of an unknown variable", "https://ads.example.com/?id=[session.id]", map[string]string{"session.name": "test"}, 1, `invalid value "session.name"`},
+		{"a value without a namespace", "https://ads.example.com/?id=[session.id]", map[string]string{"id": "test"}, 1, `invalid value "id"`},
+	} {
+		resp := runRenderAdsUrl(c.template, c.values)
+		if resp.Error == nil {
+			t.Errorf("expected an error for %s", c.description)
+			continue
+		}
+		if resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != c.argument {
+			t.Errorf("expected the error for %s to point at argument %d, got %v", c.description, c.argument, resp.Error.FunctionArgument)
+		}
+		if !strings.Contains(resp.Error.Text, c.message) {
+			t.Errorf("expected the error for %s to contain %q, got %q", c.description, c.message, resp.Error.Text)
+		}
+	}
+}
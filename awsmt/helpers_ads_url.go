@@ -0,0 +1,181 @@
+package awsmt
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// adsUrlVariable is a dynamic variable such as [session.id] in an ad decision server URL template. Start and End
+// are the byte offsets of the brackets in the template.
+type adsUrlVariable struct {
+	Namespace string
+	Name      string
+	Start     int
+	End       int
+}
+
+func (v adsUrlVariable) String() string {
+	return v.Namespace + "." + v.Name
+}
+
+// adsUrlSessionVariables lists the names MediaTailor accepts in the session and avail namespaces. SCTE-35 variables
+// depend on the ad marker of the stream and player_params on the player, so their names are not checked.
+var adsUrlSessionVariables = map[string][]string{
+	"session": {"avail_duration_ms", "avail_duration_secs", "client_ip", "id", "referer", "user_agent", "uuid"},
+	"avail":   {"index", "random"},
+}
+
+var adsUrlNamespaces = []string{"avail", "player_params", "scte", "session"}
+
+// adsUrlSampleValues are used by render_ads_url for session and avail variables that are not given explicitly.
+var adsUrlSampleValues = map[string]string{
+	"session.avail_duration_ms":   "30000",
+	"session.avail_duration_secs": "30",
+	"session.client_ip":           "192.0.2.1",
+	"session.id":                  "c0ffee00-0000-4000-8000-000000000000",
+	"session.referer":             "https://example.com/",
+	"session.user_agent":          "Mozilla/5.0",
+	"session.uuid":                "c0ffee00-0000-4000-8000-000000000000",
+	"avail.index":                 "1",
+	"avail.random":                "1234567890",
+}
+
+// parseAdsUrlTemplate returns the dynamic variables of an ad decision server URL template, failing on unbalanced
+// brackets and unknown namespaces. Bracketed IPv6 addresses are not variables.
+func parseAdsUrlTemplate(template string) ([]adsUrlVariable, error) {
+	var variables []adsUrlVariable
+	for i := 0; i < len(template); i++ {
+		switch template[i] {
+		case ']':
+			return nil, fmt.Errorf("unexpected ']' at position %d", i)
+		case '[':
+			end := strings.IndexAny(template[i+1:], "[]")
+			if end < 0 || template[i+1+end] != ']' {
+				return nil, fmt.Errorf("unclosed '[' at position %d", i)
+			}
+			end += i + 1
+			content := template[i+1 : end]
+
+			if net.ParseIP(content) != nil {
+				i = end
+				continue
+			}
+
+			variable, err := parseAdsUrlVariable(content)
+			if err != nil {
+				return nil, err
+			}
+			variable.Start, variable.End = i, end
+			variables = append(variables, variable)
+			i = end
+		}
+	}
+	return variables, nil
+}
+
+func parseAdsUrlVariable(content string) (adsUrlVariable, error) {
+	namespace, name, found := strings.Cut(content, ".")
+	if !found || name == "" {
+		return adsUrlVariable{}, fmt.Errorf("variable [%s] must have the format [namespace.name]", content)
+	}
+
+	valid := false
+	for _, n := range adsUrlNamespaces {
+		valid = valid || n == namespace
+	}
+	if !valid {
+		return adsUrlVariable{}, fmt.Errorf("variable [%s] uses the unknown namespace %q, expected one of %s", content, namespace, strings.Join(adsUrlNamespaces, ", "))
+	}
+
+	if names, ok := adsUrlSessionVariables[namespace]; ok {
+		known := false
+		for _, n := range names {
+			known = known || n == name
+		}
+		if !known {
+			return adsUrlVariable{}, fmt.Errorf("unknown variable [%s], expected one of %s.%s", content, namespace, strings.Join(names, ", "+namespace+"."))
+		}
+	}
+
+	return adsUrlVariable{Namespace: namespace, Name: name}, nil
+}
+
+// adsUrlHostVariables returns the player_params variables used in the host of a URL template. MediaTailor only
+// resolves those through configuration aliases.
+func adsUrlHostVariables(template string, variables []adsUrlVariable) []adsUrlVariable {
+	hostStart := strings.Index(template, "://")
+	if hostStart < 0 {
+		return nil
+	}
+	hostStart += 3
+	hostEnd := len(template)
+	for i := hostStart; i < len(template); i++ {
+		if template[i] == '/' || template[i] == '?' || template[i] == '#' {
+			hostEnd = i
+			break
+		}
+	}
+
+	var result []adsUrlVariable
+	for _, v := range variables {
+		if v.Namespace == "player_params" && v.Start >= hostStart && v.End < hostEnd {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// adsUrlValuesError is returned by renderAdsUrl when the values, rather than the template, are wrong.
+type adsUrlValuesError struct {
+	message string
+}
+
+func (e adsUrlValuesError) Error() string {
+	return e.message
+}
+
+// renderAdsUrl expands the variables of a URL template. Values not given fall back to adsUrlSampleValues; SCTE-35
+// and player_params variables have no sample value and must be given. Values must be keyed by valid variable names.
+func renderAdsUrl(template string, values map[string]string) (string, error) {
+	variables, err := parseAdsUrlTemplate(template)
+	if err != nil {
+		return "", err
+	}
+
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, err := parseAdsUrlVariable(key); err != nil {
+			return "", adsUrlValuesError{fmt.Sprintf("invalid value %q: %s", key, err)}
+		}
+	}
+
+	var missing []string
+	var rendered strings.Builder
+	last := 0
+	for _, v := range variables {
+		value, ok := values[v.String()]
+		if !ok {
+			value, ok = adsUrlSampleValues[v.String()]
+		}
+		if !ok {
+			missing = append(missing, v.String())
+			continue
+		}
+		rendered.WriteString(template[last:v.Start])
+		rendered.WriteString(value)
+		last = v.End + 1
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", adsUrlValuesError{"no value given for " + strings.Join(missing, ", ")}
+	}
+	rendered.WriteString(template[last:])
+
+	return rendered.String(), nil
+}
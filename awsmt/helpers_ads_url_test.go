@@ -0,0 +1,65 @@
+package awsmt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAdsUrlTemplate(t *testing.T) {
+	variables, err := parseAdsUrlTemplate("https://[player_params.domain].example.com/ads?id=[session.id]&n=[avail.index]&e=[scte.event_id]&ip=[::1]")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var names []string
+	for _, v := range variables {
+		names = append(names, v.String())
+	}
+	expected := []string{"player_params.domain", "session.id", "avail.index", "scte.event_id"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestParseAdsUrlTemplateErrors(t *testing.T) {
+	for _, template := range []string{
+		"https://example.com/?id=[session.id",
+		"https://example.com/?id=session.id]",
+		"https://example.com/?id=[session.[id]]",
+		"https://example.com/?id=[sesion.id]",
+		"https://example.com/?id=[session.identifier]",
+		"https://example.com/?id=[avail]",
+		"https://example.com/?id=[player_params.]",
+	} {
+		if _, err := parseAdsUrlTemplate(template); err == nil {
+			t.Errorf("expected an error for %q", template)
+		}
+	}
+}
+
+func TestAdsUrlHostVariables(t *testing.T) {
+	template := "https://[player_params.domain].example.com/ads?[player_params.query]"
+	variables, _ := parseAdsUrlTemplate(template)
+	host := adsUrlHostVariables(template, variables)
+	if len(host) != 1 || host[0].String() != "player_params.domain" {
+		t.Errorf("expected only player_params.domain in the host, got %v", host)
+	}
+}
+
+func TestRenderAdsUrl(t *testing.T) {
+	rendered, err := renderAdsUrl("https://ads.example.com/?id=[session.id]&n=[avail.index]&c=[player_params.c]", map[string]string{
+		"player_params.c": "sports",
+		"avail.index":     "3",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := "https://ads.example.com/?id=c0ffee00-0000-4000-8000-000000000000&n=3&c=sports"
+	if rendered != expected {
+		t.Errorf("expected %q, got %q", expected, rendered)
+	}
+
+	if _, err := renderAdsUrl("https://ads.example.com/?c=[player_params.c]&e=[scte.event_id]", nil); err == nil {
+		t.Error("expected an error for variables without a value")
+	}
+}
@@ -0,0 +1,199 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"strings"
+)
+
+// mediaTailorArn holds the parts of a MediaTailor ARN the provider needs to address a resource.
+type mediaTailorArn struct {
+	Partition    string
+	Region       string
+	AccountID    string
+	ResourceType string
+	Names        []string
+}
+
+// mediaTailorArnNameCount maps every MediaTailor resource type to the number of names in its ARN resource part.
+// VOD and live sources are nested in a source location, so their ARNs carry both names.
+var mediaTailorArnNameCount = map[string]int{
+	"channel":               1,
+	"playbackConfiguration": 1,
+	"sourceLocation":        1,
+	"vodSource":             2,
+	"liveSource":            2,
+}
+
+func parseMediaTailorArn(value string) (mediaTailorArn, error) {
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		return mediaTailorArn{}, err
+	}
+	if parsed.Service != "mediatailor" {
+		return mediaTailorArn{}, fmt.Errorf("%q is not a MediaTailor ARN", value)
+	}
+
+	parts := strings.Split(parsed.Resource, "/")
+	resourceType := parts[0]
+	count, ok := mediaTailorArnNameCount[resourceType]
+	if !ok {
+		return mediaTailorArn{}, fmt.Errorf("unknown MediaTailor resource type %q in ARN %q", resourceType, value)
+	}
+
+	names := parts[1:]
+	if len(names) != count {
+		return mediaTailorArn{}, fmt.Errorf("expected %d name(s) after %q in ARN %q, got %d", count, resourceType, value, len(names))
+	}
+	for _, name := range names {
+		if name == "" {
+			return mediaTailorArn{}, fmt.Errorf("ARN %q contains an empty name", value)
+		}
+	}
+
+	return mediaTailorArn{
+		Partition:    parsed.Partition,
+		Region:       parsed.Region,
+		AccountID:    parsed.AccountID,
+		ResourceType: resourceType,
+		Names:        names,
+	}, nil
+}
+
+// buildMediaTailorArn returns the ARN of a MediaTailor resource. The partition is derived from the region and falls
+// back to "aws" for regions the SDK does not know yet.
+func buildMediaTailorArn(region, accountID, resourceType string, names ...string) (string, error) {
+	count, ok := mediaTailorArnNameCount[resourceType]
+	if !ok {
+		return "", fmt.Errorf("unknown MediaTailor resource type %q", resourceType)
+	}
+	if len(names) != count {
+		return "", fmt.Errorf("expected %d name(s) for a %s, got %d", count, resourceType, len(names))
+	}
+	if region == "" {
+		return "", fmt.Errorf("region must not be empty")
+	}
+	if len(accountID) != 12 || strings.Trim(accountID, "0123456789") != "" {
+		return "", fmt.Errorf("account ID must consist of 12 digits, got %q", accountID)
+	}
+	for _, name := range names {
+		if name == "" || strings.Contains(name, "/") {
+			return "", fmt.Errorf("name %q must not be empty or contain a slash", name)
+		}
+	}
+
+	partition := "aws"
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+
+	return arn.ARN{
+		Partition: partition,
+		Service:   "mediatailor",
+		Region:    region,
+		AccountID: accountID,
+		Resource:  strings.Join(append([]string{resourceType}, names...), "/"),
+	}.String(), nil
+}
+
+// namesFromArn parses a MediaTailor ARN and returns the resource names, failing if the ARN refers to another
+// resource type.
+func namesFromArn(value string, resourceType string) ([]string, error) {
+	parsed, err := parseMediaTailorArn(value)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.ResourceType != resourceType {
+		return nil, fmt.Errorf("expected an ARN for a %s, got one for a %s: %q", resourceType, parsed.ResourceType, value)
+	}
+	return parsed.Names, nil
+}
+
+// resolveNamesFromArn returns the resource names of a MediaTailor ARN, after checking that the ARN is the one of the
+// resource with these names in the account and region of the client. MediaTailor addresses resources by name only, so
+// an ARN of another partition, account or region would otherwise resolve to a resource with the same name.
+func resolveNamesFromArn(ctx context.Context, client mediaTailorClient, value string, resourceType string) ([]string, error) {
+	names, err := namesFromArn(value, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := describeArn(ctx, client, resourceType, names)
+	if err != nil {
+		return nil, err
+	}
+	if actual != value {
+		return nil, fmt.Errorf("ARN %q does not belong to the account and region of the provider, where the %s with the same name has the ARN %q", value, resourceType, actual)
+	}
+	return names, nil
+}
+
+// describeArn returns the ARN of the resource with the given names, as reported by MediaTailor.
+func describeArn(ctx context.Context, client mediaTailorClient, resourceType string, names []string) (string, error) {
+	switch resourceType {
+	case "channel":
+		output, err := client.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: &names[0]})
+		if err != nil {
+			return "", err
+		}
+		return aws.StringValue(output.Arn), nil
+	case "playbackConfiguration":
+		output, err := client.GetPlaybackConfigurationWithContext(ctx, &mediatailor.GetPlaybackConfigurationInput{Name: &names[0]})
+		if err != nil {
+			return "", err
+		}
+		return aws.StringValue(output.PlaybackConfigurationArn), nil
+	case "sourceLocation":
+		output, err := client.DescribeSourceLocationWithContext(ctx, &mediatailor.DescribeSourceLocationInput{SourceLocationName: &names[0]})
+		if err != nil {
+			return "", err
+		}
+		return aws.StringValue(output.Arn), nil
+	case "vodSource":
+		output, err := client.DescribeVodSourceWithContext(ctx, &mediatailor.DescribeVodSourceInput{SourceLocationName: &names[0], VodSourceName: &names[1]})
+		if err != nil {
+			return "", err
+		}
+		return aws.StringValue(output.Arn), nil
+	case "liveSource":
+		output, err := client.DescribeLiveSourceWithContext(ctx, &mediatailor.DescribeLiveSourceInput{SourceLocationName: &names[0], LiveSourceName: &names[1]})
+		if err != nil {
+			return "", err
+		}
+		return aws.StringValue(output.Arn), nil
+	default:
+		return "", fmt.Errorf("unknown MediaTailor resource type %q", resourceType)
+	}
+}
+
+// importNamesFromID returns the resource names encoded in an import identifier, which is either a MediaTailor ARN
+// of the given resource type or the names themselves, separated by commas. ARNs are checked with
+// resolveNamesFromArn, so that an ARN of another account or region does not import a resource with the same name.
+func importNamesFromID(ctx context.Context, client mediaTailorClient, id string, resourceType string) ([]string, error) {
+	if strings.HasPrefix(id, "arn:") {
+		return resolveNamesFromArn(ctx, client, id, resourceType)
+	}
+
+	count := mediaTailorArnNameCount[resourceType]
+	names := strings.Split(id, ",")
+	if count == 1 {
+		names = []string{id}
+	}
+	// Nested resources are imported by the name of their source location and their own name.
+	format := "name"
+	if count == 2 {
+		format = "source_location_name,name"
+	}
+	if len(names) != count {
+		return nil, fmt.Errorf("expected import identifier with format: %s or an ARN. Got: %q", format, id)
+	}
+	for _, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("expected import identifier with format: %s or an ARN, without empty names. Got: %q", format, id)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,151 @@
+package awsmt
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMediaTailorArn(t *testing.T) {
+	parsed, err := parseMediaTailorArn("arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location/source")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := mediaTailorArn{
+		Partition:    "aws",
+		Region:       "eu-central-1",
+		AccountID:    "123456789012",
+		ResourceType: "vodSource",
+		Names:        []string{"location", "source"},
+	}
+	if !reflect.DeepEqual(parsed, expected) {
+		t.Errorf("expected %+v, got %+v", expected, parsed)
+	}
+}
+
+func TestParseMediaTailorArnErrors(t *testing.T) {
+	for _, value := range []string{
+		"channel/test",
+		"arn:aws:s3:::bucket",
+		"arn:aws:mediatailor:eu-central-1:123456789012:unknown/test",
+		"arn:aws:mediatailor:eu-central-1:123456789012:channel/a/b",
+		"arn:aws:mediatailor:eu-central-1:123456789012:liveSource/location",
+		"arn:aws:mediatailor:eu-central-1:123456789012:sourceLocation/",
+	} {
+		if _, err := parseMediaTailorArn(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestNamesFromArnWrongType(t *testing.T) {
+	_, err := namesFromArn("arn:aws:mediatailor:eu-central-1:123456789012:channel/test", "sourceLocation")
+	if err == nil {
+		t.Fatal("expected an error for a channel ARN")
+	}
+}
+
+func TestImportNamesFromID(t *testing.T) {
+	ctx := context.Background()
+	fake := newSelectorsFake(t)
+	if _, err := fake.CreateChannelWithContext(ctx, fakeChannelInput("test")); err != nil {
+		t.Fatal(err)
+	}
+
+	for id, expected := range map[string][]string{
+		"test": {"test"},
+		"arn:aws:mediatailor:eu-central-1:123456789012:channel/test": {"test"},
+	} {
+		names, err := importNamesFromID(ctx, fake, id, "channel")
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", id, err)
+		}
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("expected %v for %q, got %v", expected, id, names)
+		}
+	}
+
+	for id, expected := range map[string][]string{
+		"location,source": {"location", "source"},
+		"arn:aws:mediatailor:eu-central-1:123456789012:liveSource/second/live": {"second", "live"},
+	} {
+		names, err := importNamesFromID(ctx, fake, id, "liveSource")
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", id, err)
+		}
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("expected %v for %q, got %v", expected, id, names)
+		}
+	}
+}
+
+func TestImportNamesFromIDErrors(t *testing.T) {
+	ctx := context.Background()
+	fake := newSelectorsFake(t)
+	for _, id := range []string{
+		"source",
+		"location,",
+		"arn:aws:mediatailor:eu-central-1:123456789012:vodSource/second/vod",
+		"arn:aws:mediatailor:eu-central-1:111122223333:liveSource/second/live",
+		"arn:aws:mediatailor:us-west-2:123456789012:liveSource/second/live",
+		"arn:aws:mediatailor:eu-central-1:123456789012:liveSource/second/missing",
+	} {
+		if _, err := importNamesFromID(ctx, fake, id, "liveSource"); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+
+	for id, c := range map[string]struct {
+		resourceType string
+		format       string
+	}{
+		"":          {"channel", "format: name or an ARN"},
+		"source":    {"vodSource", "format: source_location_name,name or an ARN"},
+		"location,": {"liveSource", "format: source_location_name,name or an ARN"},
+	} {
+		_, err := importNamesFromID(ctx, fake, id, c.resourceType)
+		if err == nil || !strings.Contains(err.Error(), c.format) {
+			t.Errorf("expected the error for the %s import identifier %q to name the %s, got %v", c.resourceType, id, c.format, err)
+		}
+	}
+}
+
+func TestBuildMediaTailorArn(t *testing.T) {
+	for _, c := range []struct {
+		region   string
+		resource string
+		names    []string
+		expected string
+	}{
+		{"eu-central-1", "channel", []string{"test"}, "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"},
+		{"cn-north-1", "sourceLocation", []string{"location"}, "arn:aws-cn:mediatailor:cn-north-1:123456789012:sourceLocation/location"},
+		{"us-east-1", "liveSource", []string{"location", "source"}, "arn:aws:mediatailor:us-east-1:123456789012:liveSource/location/source"},
+	} {
+		value, err := buildMediaTailorArn(c.region, "123456789012", c.resource, c.names...)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if value != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, value)
+		}
+	}
+}
+
+func TestBuildMediaTailorArnErrors(t *testing.T) {
+	for _, c := range []struct {
+		account  string
+		resource string
+		names    []string
+	}{
+		{"12345", "channel", []string{"test"}},
+		{"123456789012", "unknown", []string{"test"}},
+		{"123456789012", "vodSource", []string{"test"}},
+		{"123456789012", "channel", []string{"a/b"}},
+		{"123456789012", "channel", []string{""}},
+	} {
+		if _, err := buildMediaTailorArn("eu-central-1", c.account, c.resource, c.names...); err == nil {
+			t.Errorf("expected an error for %+v", c)
+		}
+	}
+}
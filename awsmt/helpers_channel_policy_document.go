@@ -0,0 +1,147 @@
+package awsmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// policyDocument and policyStatement mirror the IAM policy grammar. Principal, Action, Resource and condition values
+// hold either a single string or a list of strings, the same way the AWS console and aws_iam_policy_document
+// render them.
+type policyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []policyStatement `json:"Statement"`
+}
+
+type policyStatement struct {
+	Sid       string                            `json:"Sid,omitempty"`
+	Effect    string                            `json:"Effect"`
+	Principal interface{}                       `json:"Principal,omitempty"`
+	Action    interface{}                       `json:"Action"`
+	Resource  interface{}                       `json:"Resource"`
+	Condition map[string]map[string]interface{} `json:"Condition,omitempty"`
+}
+
+func stringOrList(values []string) interface{} {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return values
+}
+
+// publicGetManifestStatement allows anyone to request manifests of the given channel, which is what a channel
+// without a CDN authorizing requests needs.
+func publicGetManifestStatement(channelArn string) policyStatement {
+	return policyStatement{
+		Sid:       "AllowAnonymousGetManifest",
+		Effect:    "Allow",
+		Principal: "*",
+		Action:    "mediatailor:GetManifest",
+		Resource:  channelArn,
+	}
+}
+
+func renderChannelPolicyDocument(data channelPolicyDocumentModel) (string, error) {
+	var statements []policyStatement
+
+	if data.PublicGetManifest != nil && *data.PublicGetManifest {
+		if data.ChannelArn == nil {
+			return "", fmt.Errorf("channel_arn is required when public_get_manifest is true")
+		}
+		statements = append(statements, publicGetManifestStatement(*data.ChannelArn))
+	}
+
+	for i, s := range data.Statements {
+		statement, err := renderChannelPolicyStatement(s, data.ChannelArn)
+		if err != nil {
+			return "", fmt.Errorf("statement %d: %w", i, err)
+		}
+		statements = append(statements, statement)
+	}
+
+	if len(statements) == 0 {
+		return "", fmt.Errorf("the policy document needs at least one statement or public_get_manifest set to true")
+	}
+
+	rendered, err := json.Marshal(policyDocument{Version: "2012-10-17", Statement: statements})
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
+func renderChannelPolicyStatement(s channelPolicyStatementModel, channelArn *string) (policyStatement, error) {
+	statement := policyStatement{
+		Effect: "Allow",
+		Action: stringOrList(s.Actions),
+	}
+	if s.Sid != nil {
+		statement.Sid = *s.Sid
+	}
+	if s.Effect != nil {
+		statement.Effect = *s.Effect
+	}
+
+	resources := s.Resources
+	if len(resources) == 0 {
+		if channelArn == nil {
+			return statement, fmt.Errorf("no resources given and channel_arn is not set")
+		}
+		resources = []string{*channelArn}
+	}
+	statement.Resource = stringOrList(resources)
+
+	// MediaTailor rejects channel policies with statements that apply to no principal.
+	if len(s.Principals) == 0 {
+		return statement, fmt.Errorf("at least one principal is required")
+	}
+	principals := map[string][]string{}
+	for _, p := range s.Principals {
+		if *p.Type == "*" {
+			statement.Principal = "*"
+			continue
+		}
+		principals[*p.Type] = append(principals[*p.Type], p.Identifiers...)
+	}
+	if len(principals) > 0 {
+		if statement.Principal != nil {
+			return statement, fmt.Errorf("the wildcard principal cannot be combined with other principals")
+		}
+		principal := map[string]interface{}{}
+		for principalType, identifiers := range principals {
+			principal[principalType] = stringOrList(identifiers)
+		}
+		statement.Principal = principal
+	}
+
+	if len(s.Conditions) > 0 {
+		statement.Condition = map[string]map[string]interface{}{}
+		for test, variables := range mergeConditions(s.Conditions) {
+			statement.Condition[test] = map[string]interface{}{}
+			for variable, values := range variables {
+				statement.Condition[test][variable] = stringOrList(values)
+			}
+		}
+	}
+
+	return statement, nil
+}
+
+// mergeConditions groups the condition values by test and variable. A policy holds a single entry per test and
+// variable, so the values of conditions with the same test and variable are merged, the same way
+// aws_iam_policy_document does.
+func mergeConditions(conditions []channelPolicyConditionModel) map[string]map[string][]string {
+	merged := map[string]map[string][]string{}
+	for _, c := range conditions {
+		if merged[*c.Test] == nil {
+			merged[*c.Test] = map[string][]string{}
+		}
+		for _, value := range c.Values {
+			if !slices.Contains(merged[*c.Test][*c.Variable], value) {
+				merged[*c.Test][*c.Variable] = append(merged[*c.Test][*c.Variable], value)
+			}
+		}
+	}
+	return merged
+}
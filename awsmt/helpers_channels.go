@@ -1,11 +1,9 @@
 package awsmt
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
+	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
-	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"reflect"
 	"time"
 )
 
@@ -64,12 +62,12 @@ func readChannelToState(state channelModel, channel mediatailor.DescribeChannelO
 }
 
 // POLICY
-func createChannelPolicy(channelName *string, policy *string, client *mediatailor.MediaTailor) error {
+func createChannelPolicy(ctx context.Context, channelName *string, policy *string, client mediaTailorClient) error {
 	putChannelPolicyParams := mediatailor.PutChannelPolicyInput{
 		ChannelName: channelName,
 		Policy:      policy,
 	}
-	_, err := client.PutChannelPolicy(&putChannelPolicyParams)
+	_, err := client.PutChannelPolicyWithContext(ctx, &putChannelPolicyParams)
 	if err != nil {
 		return err
 	}
@@ -131,14 +129,9 @@ func getDashPlaylistSettings(settings *dashPlaylistSettingsModel) *mediatailor.D
 
 func getHLSPlaylistSettings(settings *hlsPlaylistSettingsModel) *mediatailor.HlsPlaylistSettings {
 	hlsSettings := &mediatailor.HlsPlaylistSettings{}
-	if settings.AdMarkupType != nil && len(settings.AdMarkupType) > 0 {
-		for _, value := range settings.AdMarkupType {
-			temp := value
-			hlsSettings.AdMarkupType = append(hlsSettings.AdMarkupType, temp)
-		}
-	} else if settings.AdMarkupType == nil {
-		temp := "DATERANGE"
-		hlsSettings.AdMarkupType = append(hlsSettings.AdMarkupType, &temp)
+	for _, value := range settings.AdMarkupType {
+		temp := value
+		hlsSettings.AdMarkupType = append(hlsSettings.AdMarkupType, temp)
 	}
 	if settings.ManifestWindowSeconds != nil {
 		hlsSettings.ManifestWindowSeconds = settings.ManifestWindowSeconds
@@ -171,11 +164,11 @@ func readChannelComputedValuesToPlan(plan channelModel, arn *string, channelName
 	plan.Name = channelName
 
 	if creationTime != nil {
-		plan.CreationTime = types.StringValue((aws.TimeValue(creationTime)).String())
+		plan.CreationTime = timestampValue(creationTime)
 	}
 
 	if lastModifiedTime != nil {
-		plan.LastModifiedTime = types.StringValue((aws.TimeValue(lastModifiedTime)).String())
+		plan.LastModifiedTime = timestampValue(lastModifiedTime)
 	}
 
 	return plan
@@ -282,9 +275,9 @@ func readOptionalValuesToPlan(plan channelModel, playbackMode *string, tags map[
 	return plan
 }
 
-func stopChannel(state *string, channelName *string, client *mediatailor.MediaTailor) error {
+func stopChannel(ctx context.Context, state *string, channelName *string, client mediaTailorClient) error {
 	if *state == "RUNNING" {
-		_, err := client.StopChannel(&mediatailor.StopChannelInput{ChannelName: channelName})
+		_, err := client.StopChannelWithContext(ctx, &mediatailor.StopChannelInput{ChannelName: channelName})
 		if err != nil {
 			return err
 		}
@@ -292,24 +285,72 @@ func stopChannel(state *string, channelName *string, client *mediatailor.MediaTa
 	return nil
 }
 
-func updatePolicy(plan *channelModel, channelName *string, oldPolicy jsontypes.Normalized, newPolicy jsontypes.Normalized, client *mediatailor.MediaTailor) (channelModel, error) {
-	if !reflect.DeepEqual(oldPolicy, newPolicy) {
+func updatePolicy(ctx context.Context, plan *channelModel, channelName *string, oldPolicy iamPolicy, newPolicy iamPolicy, client mediaTailorClient) (channelModel, error) {
+	unchanged := oldPolicy.IsNull() && newPolicy.IsNull()
+	if !oldPolicy.IsNull() && !newPolicy.IsNull() {
+		equal, err := iamPoliciesEqual(oldPolicy.ValueString(), newPolicy.ValueString())
+		if err != nil {
+			return *plan, err
+		}
+		unchanged = equal
+	}
+
+	if !unchanged {
 		if !newPolicy.IsNull() {
 			policy := newPolicy.ValueString()
 			plan.Policy = newPolicy
-			_, err := client.PutChannelPolicy(&mediatailor.PutChannelPolicyInput{ChannelName: channelName, Policy: &policy})
+			_, err := client.PutChannelPolicyWithContext(ctx, &mediatailor.PutChannelPolicyInput{ChannelName: channelName, Policy: &policy})
 			if err != nil {
 				return *plan, err
 			}
 		} else if newPolicy.IsNull() {
-			plan.Policy = jsontypes.NewNormalizedNull()
-			_, err := client.DeleteChannelPolicy(&mediatailor.DeleteChannelPolicyInput{ChannelName: channelName})
+			plan.Policy = newIamPolicyNull()
+			_, err := client.DeleteChannelPolicyWithContext(ctx, &mediatailor.DeleteChannelPolicyInput{ChannelName: channelName})
 			if err != nil {
 				return *plan, err
 			}
 		}
 	} else {
-		plan.Policy = oldPolicy
+		plan.Policy = newPolicy
 	}
 	return *plan, nil
 }
+
+// getChannelNameFromSelectors resolves the name of the channel a data source refers to, using whichever of the
+// name, arn or tags selectors is set.
+func getChannelNameFromSelectors(ctx context.Context, client mediaTailorClient, data channelDataSourceModel) (*string, error) {
+	if !data.Arn.IsNull() {
+		names, err := resolveNamesFromArn(ctx, client, data.Arn.ValueString(), "channel")
+		if err != nil {
+			return nil, err
+		}
+		return &names[0], nil
+	}
+	if data.Tags != nil {
+		return findChannelByTags(ctx, client, data.Tags)
+	}
+	return data.Name, nil
+}
+
+func findChannelByTags(ctx context.Context, client mediaTailorClient, tags map[string]*string) (*string, error) {
+	if len(tags) == 0 {
+		return nil, errEmptyTagFilter
+	}
+	var matches []string
+	err := client.ListChannelsPagesWithContext(ctx, &mediatailor.ListChannelsInput{}, func(page *mediatailor.ListChannelsOutput, _ bool) bool {
+		for _, channel := range page.Items {
+			if tagsMatch(channel.Tags, tags) {
+				matches = append(matches, *channel.ChannelName)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	name, err := singleMatch("channel", matches)
+	if err != nil {
+		return nil, err
+	}
+	return &name, nil
+}
@@ -1,39 +1,79 @@
 package awsmt
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"reflect"
+	"strings"
 )
 
-func untagResource(client *mediatailor.MediaTailor, oldTags map[string]*string, resourceArn string) error {
+func untagResource(ctx context.Context, client mediaTailorClient, oldTags map[string]*string, resourceArn string) error {
+	if len(oldTags) == 0 {
+		return nil
+	}
 	var removeTags []*string
 	for k := range oldTags {
 		removeTags = append(removeTags, aws.String(k))
 	}
-	_, err := client.UntagResource(&mediatailor.UntagResourceInput{ResourceArn: &resourceArn, TagKeys: removeTags})
+	_, err := client.UntagResourceWithContext(ctx, &mediatailor.UntagResourceInput{ResourceArn: &resourceArn, TagKeys: removeTags})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func tagResource(client *mediatailor.MediaTailor, newTags map[string]*string, resourceArn string) error {
-	_, err := client.TagResource(&mediatailor.TagResourceInput{ResourceArn: &resourceArn, Tags: newTags})
+func tagResource(ctx context.Context, client mediaTailorClient, newTags map[string]*string, resourceArn string) error {
+	if len(newTags) == 0 {
+		return nil
+	}
+	_, err := client.TagResourceWithContext(ctx, &mediatailor.TagResourceInput{ResourceArn: &resourceArn, Tags: newTags})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func updatesTags(client *mediatailor.MediaTailor, oldTags map[string]*string, newTags map[string]*string, resourceArn string) error {
+func updatesTags(ctx context.Context, client mediaTailorClient, oldTags map[string]*string, newTags map[string]*string, resourceArn string) error {
 	if !reflect.DeepEqual(oldTags, newTags) {
-		if err := untagResource(client, oldTags, resourceArn); err != nil {
+		if err := untagResource(ctx, client, oldTags, resourceArn); err != nil {
 			return err
 		}
-		if err := tagResource(client, newTags, resourceArn); err != nil {
+		if err := tagResource(ctx, client, newTags, resourceArn); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// errEmptyTagFilter is returned when a data source is looked up by an empty tags map, which would match any resource.
+var errEmptyTagFilter = errors.New("tags must contain at least one tag to look up a resource by")
+
+// tagsMatch reports whether every tag in filter is present with the same value in tags. An empty filter matches
+// nothing, see errEmptyTagFilter.
+func tagsMatch(tags map[string]*string, filter map[string]*string) bool {
+	if len(filter) == 0 {
+		return false
+	}
+	for k, v := range filter {
+		value, ok := tags[k]
+		if !ok || aws.StringValue(value) != aws.StringValue(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// singleMatch returns the only element of matches, or an error describing why the tag filter was ambiguous.
+func singleMatch(resourceType string, matches []string) (string, error) {
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s matches the given tags", resourceType)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("the given tags match %d %ss, expected exactly one: %s", len(matches), resourceType, strings.Join(matches, ", "))
+	}
+}
@@ -0,0 +1,179 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"testing"
+)
+
+func TestTagsMatch(t *testing.T) {
+	tags := map[string]*string{"Environment": aws.String("dev"), "Team": aws.String("video")}
+	tests := []struct {
+		filter   map[string]*string
+		expected bool
+	}{
+		{map[string]*string{"Environment": aws.String("dev")}, true},
+		{map[string]*string{"Environment": aws.String("dev"), "Team": aws.String("video")}, true},
+		{map[string]*string{"Environment": aws.String("prod")}, false},
+		{map[string]*string{"Owner": aws.String("dev")}, false},
+		{map[string]*string{}, false},
+		{nil, false},
+	}
+	for _, test := range tests {
+		if got := tagsMatch(tags, test.filter); got != test.expected {
+			t.Errorf("expected tagsMatch with filter %v to be %t", test.filter, test.expected)
+		}
+	}
+}
+
+func TestSingleMatch(t *testing.T) {
+	if name, err := singleMatch("channel", []string{"test"}); err != nil || name != "test" {
+		t.Errorf("expected the only match to be returned, got %q, %v", name, err)
+	}
+	if _, err := singleMatch("channel", nil); err == nil {
+		t.Error("expected an error without matches")
+	}
+	if _, err := singleMatch("channel", []string{"a", "b"}); err == nil {
+		t.Error("expected an error for several matches")
+	}
+}
+
+// newSelectorsFake returns a fake with a playback configuration and a VOD and a live source in each of two source
+// locations, all of them tagged with their name.
+func newSelectorsFake(t *testing.T) *fakeMediaTailor {
+	t.Helper()
+	ctx := context.Background()
+	fake := newFakeMediaTailor("eu-central-1", "123456789012")
+	httpPackageConfigurations := []*mediatailor.HttpPackageConfiguration{{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: aws.String("HLS")}}
+	for _, location := range []string{"first", "second"} {
+		if _, err := fake.CreateSourceLocationWithContext(ctx, &mediatailor.CreateSourceLocationInput{
+			SourceLocationName: aws.String(location),
+			HttpConfiguration:  &mediatailor.HttpConfiguration{BaseUrl: aws.String("https://example.com")},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fake.CreateVodSourceWithContext(ctx, &mediatailor.CreateVodSourceInput{
+			SourceLocationName:        aws.String(location),
+			VodSourceName:             aws.String("vod"),
+			HttpPackageConfigurations: httpPackageConfigurations,
+			Tags:                      map[string]*string{"Name": aws.String(location + "-vod")},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fake.CreateLiveSourceWithContext(ctx, &mediatailor.CreateLiveSourceInput{
+			SourceLocationName:        aws.String(location),
+			LiveSourceName:            aws.String("live"),
+			HttpPackageConfigurations: httpPackageConfigurations,
+			Tags:                      map[string]*string{"Name": aws.String(location + "-live")},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := fake.PutPlaybackConfigurationWithContext(ctx, &mediatailor.PutPlaybackConfigurationInput{
+		Name:                  aws.String("test"),
+		AdDecisionServerUrl:   aws.String("https://ads.example.com"),
+		VideoContentSourceUrl: aws.String("https://example.com"),
+		Tags:                  map[string]*string{"Name": aws.String("test")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return fake
+}
+
+func TestSourceNamesFromSelectors(t *testing.T) {
+	ctx := context.Background()
+	fake := newSelectorsFake(t)
+
+	vodTests := map[string]vodSourceDataSourceModel{
+		"by name": {SourceLocationName: aws.String("second"), Name: aws.String("vod"), Arn: types.StringNull()},
+		"by ARN":  {Arn: types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:vodSource/second/vod")},
+		"by tags": {Arn: types.StringNull(), Tags: map[string]*string{"Name": aws.String("second-vod")}},
+		"by tags in a source location": {
+			Arn: types.StringNull(), SourceLocationName: aws.String("second"), Tags: map[string]*string{"Name": aws.String("second-vod")},
+		},
+	}
+	for description, data := range vodTests {
+		sourceLocationName, name, err := getVodSourceNamesFromSelectors(ctx, fake, data)
+		if err != nil {
+			t.Fatalf("unexpected error looking up a VOD source %s: %s", description, err)
+		}
+		if *sourceLocationName != "second" || *name != "vod" {
+			t.Errorf("expected to find second/vod %s, got %s/%s", description, *sourceLocationName, *name)
+		}
+	}
+
+	liveTests := map[string]liveSourceDataSourceModel{
+		"by name": {SourceLocationName: aws.String("second"), Name: aws.String("live"), Arn: types.StringNull()},
+		"by ARN":  {Arn: types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:liveSource/second/live")},
+		"by tags": {Arn: types.StringNull(), Tags: map[string]*string{"Name": aws.String("second-live")}},
+	}
+	for description, data := range liveTests {
+		sourceLocationName, name, err := getLiveSourceNamesFromSelectors(ctx, fake, data)
+		if err != nil {
+			t.Fatalf("unexpected error looking up a live source %s: %s", description, err)
+		}
+		if *sourceLocationName != "second" || *name != "live" {
+			t.Errorf("expected to find second/live %s, got %s/%s", description, *sourceLocationName, *name)
+		}
+	}
+}
+
+func TestSourceNamesFromSelectorsErrors(t *testing.T) {
+	ctx := context.Background()
+	fake := newSelectorsFake(t)
+
+	for description, data := range map[string]vodSourceDataSourceModel{
+		"an ARN of another account":       {Arn: types.StringValue("arn:aws:mediatailor:eu-central-1:111122223333:vodSource/second/vod")},
+		"an ARN of another region":        {Arn: types.StringValue("arn:aws:mediatailor:us-east-1:123456789012:vodSource/second/vod")},
+		"an ARN of a missing VOD source":  {Arn: types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:vodSource/third/vod")},
+		"an ARN of a live source":         {Arn: types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:liveSource/second/live")},
+		"an empty tag filter":             {Arn: types.StringNull(), Tags: map[string]*string{}},
+		"tags in another source location": {Arn: types.StringNull(), SourceLocationName: aws.String("first"), Tags: map[string]*string{"Name": aws.String("second-vod")}},
+	} {
+		if _, _, err := getVodSourceNamesFromSelectors(ctx, fake, data); err == nil {
+			t.Errorf("expected an error for %s", description)
+		}
+	}
+
+	_, _, err := getLiveSourceNamesFromSelectors(ctx, fake, liveSourceDataSourceModel{Arn: types.StringNull(), Tags: map[string]*string{}})
+	if !errors.Is(err, errEmptyTagFilter) {
+		t.Errorf("expected an empty tag filter to be rejected, got %v", err)
+	}
+	_, _, err = getLiveSourceNamesFromSelectors(ctx, fake, liveSourceDataSourceModel{Arn: types.StringValue("arn:aws-cn:mediatailor:eu-central-1:123456789012:liveSource/second/live")})
+	if err == nil {
+		t.Error("expected an error for an ARN of another partition")
+	}
+}
+
+func TestPlaybackConfigurationNameFromSelectors(t *testing.T) {
+	ctx := context.Background()
+	fake := newSelectorsFake(t)
+
+	for description, data := range map[string]playbackConfigurationDataSourceModel{
+		"by name": {Name: aws.String("test"), PlaybackConfigurationArn: types.StringNull()},
+		"by ARN":  {PlaybackConfigurationArn: types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:playbackConfiguration/test")},
+		"by tags": {PlaybackConfigurationArn: types.StringNull(), Tags: map[string]*string{"Name": aws.String("test")}},
+	} {
+		name, err := getPlaybackConfigurationNameFromSelectors(ctx, fake, data)
+		if err != nil {
+			t.Fatalf("unexpected error looking up a playback configuration %s: %s", description, err)
+		}
+		if *name != "test" {
+			t.Errorf("expected to find test %s, got %s", description, *name)
+		}
+	}
+
+	for description, data := range map[string]playbackConfigurationDataSourceModel{
+		"an ARN of another account": {PlaybackConfigurationArn: types.StringValue("arn:aws:mediatailor:eu-central-1:111122223333:playbackConfiguration/test")},
+		"an ARN of a channel":       {PlaybackConfigurationArn: types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:channel/test")},
+		"an empty tag filter":       {PlaybackConfigurationArn: types.StringNull(), Tags: map[string]*string{}},
+		"tags matching nothing":     {PlaybackConfigurationArn: types.StringNull(), Tags: map[string]*string{"Name": aws.String("other")}},
+	} {
+		if _, err := getPlaybackConfigurationNameFromSelectors(ctx, fake, data); err == nil {
+			t.Errorf("expected an error for %s", description)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package awsmt
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// apiErrorClass classifies the errors returned by the MediaTailor API by what the user can do about them.
+type apiErrorClass string
+
+const (
+	apiErrorUnknown      apiErrorClass = ""
+	apiErrorBadRequest   apiErrorClass = "BadRequest"
+	apiErrorNotFound     apiErrorClass = "NotFound"
+	apiErrorConflict     apiErrorClass = "Conflict"
+	apiErrorThrottling   apiErrorClass = "Throttling"
+	apiErrorAccessDenied apiErrorClass = "AccessDenied"
+)
+
+var apiErrorCodes = map[string]apiErrorClass{
+	"BadRequestException":           apiErrorBadRequest,
+	"ValidationException":           apiErrorBadRequest,
+	"InvalidParameterException":     apiErrorBadRequest,
+	request.InvalidParameterErrCode: apiErrorBadRequest,
+	"NotFoundException":             apiErrorNotFound,
+	"ResourceNotFoundException":     apiErrorNotFound,
+	"ConflictException":             apiErrorConflict,
+	"ThrottlingException":           apiErrorThrottling,
+	"TooManyRequestsException":      apiErrorThrottling,
+	"AccessDeniedException":         apiErrorAccessDenied,
+	"ForbiddenException":            apiErrorAccessDenied,
+	"UnrecognizedClientException":   apiErrorAccessDenied,
+	"ExpiredTokenException":         apiErrorAccessDenied,
+}
+
+var apiErrorStatusCodes = map[int]apiErrorClass{
+	http.StatusBadRequest:      apiErrorBadRequest,
+	http.StatusNotFound:        apiErrorNotFound,
+	http.StatusConflict:        apiErrorConflict,
+	http.StatusTooManyRequests: apiErrorThrottling,
+	http.StatusUnauthorized:    apiErrorAccessDenied,
+	http.StatusForbidden:       apiErrorAccessDenied,
+}
+
+var apiErrorClassHints = map[apiErrorClass]string{
+	apiErrorNotFound:     "The resource might have been deleted outside of Terraform.",
+	apiErrorConflict:     "Another operation on the resource is in progress. Wait for it to finish and apply again.",
+	apiErrorThrottling:   "MediaTailor kept throttling the request after the retries of the AWS SDK. Apply again, or lower the number of concurrent operations with -parallelism.",
+	apiErrorAccessDenied: "Check that the credentials of the provider have not expired and are allowed to call the MediaTailor API.",
+}
+
+// apiErrorMessageHints are the remediation hints for known error messages.
+var apiErrorMessageHints = []struct {
+	pattern *regexp.Regexp
+	hint    string
+}{
+	{regexp.MustCompile(`(?i)channel .*must be stopped`), `A channel must be STOPPED to update its outputs or to be deleted. Stop the channel, or set channel_state to "STOPPED", and apply again.`},
+	{regexp.MustCompile(`(?i)filler slate`), `A filler slate can only be set on a channel with the "LINEAR" playback_mode.`},
+	{regexp.MustCompile(`(?i)cannot be deleted while it contains`), "Delete the VOD and live sources of the source location first."},
+	{regexp.MustCompile(`(?i)already exists`), "Import the existing resource with terraform import, or choose another name."},
+}
+
+// apiErrorFields maps the names of request fields, as they appear in MediaTailor error messages, to the attributes they
+// are configured with. Names are matched regardless of case and of spaces between their words, so that "PlaybackMode"
+// also matches "playbackMode" and "playback mode".
+type apiErrorFields map[string]path.Path
+
+// classifyAPIError classifies the error by its code, or by its HTTP status if the code is not known.
+func classifyAPIError(err error) apiErrorClass {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return apiErrorUnknown
+	}
+	if class, ok := apiErrorCodes[awsErr.Code()]; ok {
+		return class
+	}
+	var failure awserr.RequestFailure
+	if errors.As(err, &failure) {
+		return apiErrorStatusCodes[failure.StatusCode()]
+	}
+	return apiErrorUnknown
+}
+
+func isNotFound(err error) bool {
+	return classifyAPIError(err) == apiErrorNotFound
+}
+
+// addAPIError adds a diagnostic for an error returned by the MediaTailor API, with remediation hints where they are
+// known. A rejected request is reported on the attribute of the first field its message names.
+func addAPIError(diags *diag.Diagnostics, summary string, err error, fields apiErrorFields) {
+	class := classifyAPIError(err)
+	message := err.Error()
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		message = awsErr.Message()
+	}
+
+	detail := apiErrorDetail(err)
+	if hints := apiErrorHints(class, message); len(hints) > 0 {
+		detail += "\n\n" + strings.Join(hints, "\n")
+	}
+
+	if class == apiErrorBadRequest {
+		if attributePath, ok := fields.match(message); ok {
+			diags.AddAttributeError(attributePath, summary, detail)
+			return
+		}
+	}
+	diags.AddError(summary, detail)
+}
+
+func apiErrorHints(class apiErrorClass, message string) []string {
+	var hints []string
+	if hint, ok := apiErrorClassHints[class]; ok {
+		hints = append(hints, hint)
+	}
+	for _, h := range apiErrorMessageHints {
+		if h.pattern.MatchString(message) {
+			hints = append(hints, h.hint)
+		}
+	}
+	return hints
+}
+
+// match returns the attribute of the field named first in the message. Of fields named at the same position, the
+// longest name wins.
+func (f apiErrorFields) match(message string) (path.Path, bool) {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	position, length, match := -1, 0, ""
+	for _, name := range names {
+		location := apiErrorFieldPattern(name).FindStringIndex(message)
+		if location == nil {
+			continue
+		}
+		if position == -1 || location[0] < position || (location[0] == position && location[1]-location[0] > length) {
+			position, length, match = location[0], location[1]-location[0], name
+		}
+	}
+	if match == "" {
+		return path.Empty(), false
+	}
+	return f[match], true
+}
+
+var camelCaseWordPattern = regexp.MustCompile(`[A-Z][a-z0-9]*|[a-z0-9]+`)
+
+// apiErrorFieldPattern matches the field name in any case, with optional spaces or underscores between its words and
+// an optional plural "s".
+func apiErrorFieldPattern(name string) *regexp.Regexp {
+	words := camelCaseWordPattern.FindAllString(name, -1)
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	return regexp.MustCompile(`(?i)\b` + strings.Join(words, `[\s_]?`) + `s?\b`)
+}
@@ -0,0 +1,257 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	tests := map[apiErrorClass]error{
+		apiErrorBadRequest:   awserr.New(mediatailor.ErrCodeBadRequestException, "Invalid tier PREMIUM", nil),
+		apiErrorNotFound:     fmt.Errorf("error while reading: %w", awserr.New("NotFoundException", "Channel test not found", nil)),
+		apiErrorThrottling:   awserr.NewRequestFailure(awserr.New("ThrottlingException", "Rate exceeded", nil), 400, "1"),
+		apiErrorAccessDenied: awserr.NewRequestFailure(awserr.New("UnknownError", "Forbidden", nil), 403, "2"),
+		apiErrorConflict:     awserr.NewRequestFailure(awserr.New("UnknownError", "Conflict", nil), 409, "3"),
+		apiErrorUnknown:      errors.New("connection refused"),
+	}
+	for expected, err := range tests {
+		if got := classifyAPIError(err); got != expected {
+			t.Errorf("expected %q to be classified as %q, got %q", err, expected, got)
+		}
+	}
+}
+
+func TestApiErrorFieldsMatch(t *testing.T) {
+	tests := map[string]path.Path{
+		"Invalid playback mode SPLIT": path.Root("playback_mode"),
+		"Invalid channel_name":        path.Root("name"),
+
+		"1 validation error detected: Value at 'playbackMode' failed to satisfy constraint":          path.Root("playback_mode"),
+		"Filler slates are only supported by channels with the LINEAR playback mode":                 path.Root("filler_slate"),
+		"Output index.m3u8 must specify exactly one of HlsPlaylistSettings and DashPlaylistSettings": path.Root("outputs"),
+	}
+	for message, expected := range tests {
+		got, ok := channelErrorFields.match(message)
+		if !ok || !got.Equal(expected) {
+			t.Errorf("expected %q to be reported on %s, got %s", message, expected, got)
+		}
+	}
+	if _, ok := channelErrorFields.match("Internal server error"); ok {
+		t.Error("expected a message without field names not to match")
+	}
+	if got, _ := sourceLocationErrorFields.match("Invalid base URL ftp://example.com"); !got.Equal(path.Root("http_configuration").AtName("base_url")) {
+		t.Errorf("expected the base URL to be matched, got %s", got)
+	}
+}
+
+func TestAddAPIError(t *testing.T) {
+	client := newFakeMediaTailor("eu-central-1", "123456789012")
+	ctx := context.Background()
+	if _, err := client.CreateChannelWithContext(ctx, fakeChannelInput("test")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartChannelWithContext(ctx, &mediatailor.StartChannelInput{ChannelName: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := client.DeleteChannelWithContext(ctx, &mediatailor.DeleteChannelInput{ChannelName: aws.String("test")})
+	if err == nil {
+		t.Fatal("expected a running channel not to be deleted")
+	}
+
+	var diags diag.Diagnostics
+	addAPIError(&diags, "Error while deleting channel test", err, channelErrorFields)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %v", diags)
+	}
+	d := diags.Errors()[0]
+	if _, ok := d.(diag.DiagnosticWithPath); ok {
+		t.Error("expected an error without field names not to be reported on an attribute")
+	}
+	if d.Summary() != "Error while deleting channel test" {
+		t.Errorf("unexpected summary %q", d.Summary())
+	}
+	if !strings.Contains(d.Detail(), "must be stopped") || !strings.Contains(d.Detail(), `set channel_state to "STOPPED"`) {
+		t.Errorf("expected the detail to explain how to stop the channel, got %q", d.Detail())
+	}
+
+	diags = nil
+	_, err = client.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: aws.String("missing")})
+	addAPIError(&diags, "Error while describing channel missing", err, channelErrorFields)
+	if !strings.Contains(diags.Errors()[0].Detail(), apiErrorClassHints[apiErrorNotFound]) {
+		t.Errorf("expected a hint for a missing resource, got %q", diags.Errors()[0].Detail())
+	}
+}
+
+func TestAttributeError(t *testing.T) {
+	ctx := context.Background()
+	r := &resourceChannel{client: newFakeMediaTailor("eu-central-1", "123456789012")}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	planned, err := tftypes.ValueFromJSON([]byte(`{
+		"name": "test",
+		"filler_slate": {"source_location_name": "test", "vod_source_name": "slate"},
+		"outputs": [{"manifest_name": "default", "source_group": "default", "hls_playlist_settings": {"ad_markup_type": ["DATERANGE"], "manifest_window_seconds": 60}}],
+		"playback_mode": "LOOP"
+	}`), schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := resource.CreateRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: planned},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned},
+	}
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(planned.Type(), nil)}}
+	r.Create(ctx, req, &resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %v", resp.Diagnostics)
+	}
+	d, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !d.Path().Equal(path.Root("filler_slate")) {
+		t.Errorf("expected the error to be reported on filler_slate, got %v", resp.Diagnostics.Errors()[0])
+	}
+	if strings.Contains(resp.Diagnostics.Errors()[0].Summary(), "Filler slates") {
+		t.Errorf("expected the summary not to repeat the detail, got %q", resp.Diagnostics.Errors()[0].Summary())
+	}
+}
+
+// TestStaticDiagnosticSummaries makes sure that no error text ends up in the summary of a diagnostic, where Terraform
+// shows it as the headline of the error. Errors belong in the detail.
+func TestStaticDiagnosticSummaries(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	summaryArgument := map[string]int{"AddError": 0, "AddWarning": 0, "AddAttributeError": 1, "AddAttributeWarning": 1, "addAPIError": 1}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(parsed, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			var name string
+			switch fun := call.Fun.(type) {
+			case *ast.Ident:
+				name = fun.Name
+			case *ast.SelectorExpr:
+				name = fun.Sel.Name
+			}
+			i, ok := summaryArgument[name]
+			if !ok || i >= len(call.Args) {
+				return true
+			}
+			ast.Inspect(call.Args[i], func(node ast.Node) bool {
+				if ident, ok := node.(*ast.Ident); ok && (ident.Name == "err" || ident.Name == "Error") {
+					t.Errorf("%s: the summary of the diagnostic contains an error", fset.Position(call.Pos()))
+					return false
+				}
+				return true
+			})
+			return true
+		})
+	}
+}
+
+func TestReadMissingResource(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeMediaTailor("eu-central-1", "123456789012")
+	tests := map[string]struct {
+		resource resource.Resource
+		state    string
+	}{
+		"awsmt_channel":                {&resourceChannel{client: client}, `{"name": "missing"}`},
+		"awsmt_live_source":            {&resourceLiveSource{client: client}, `{"name": "missing", "source_location_name": "missing"}`},
+		"awsmt_playback_configuration": {&resourcePlaybackConfiguration{client: client}, `{"name": "missing"}`},
+		"awsmt_source_location":        {&resourceSourceLocation{client: client}, `{"name": "missing"}`},
+		"awsmt_vod_source":             {&resourceVodSource{client: client}, `{"name": "missing", "source_location_name": "missing"}`},
+	}
+	for resourceType, test := range tests {
+		schemaResp := resource.SchemaResponse{}
+		test.resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		state, err := tftypes.ValueFromJSON([]byte(test.state), schemaResp.Schema.Type().TerraformType(ctx))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := resource.ReadRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+		resp := resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+		test.resource.Read(ctx, req, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Errorf("expected a missing %s to be read without errors, got %v", resourceType, resp.Diagnostics)
+		}
+		if !resp.State.Raw.IsNull() {
+			t.Errorf("expected a missing %s to be removed from the state", resourceType)
+		}
+	}
+}
+
+// TestSourceUpdateError updates a VOD and a live source with two HTTP package configurations for the same source
+// group and type, which the fake rejects, and expects the error to be reported without touching the state.
+func TestSourceUpdateError(t *testing.T) {
+	ctx := context.Background()
+	fake := newSelectorsFake(t)
+	state := `{"name": "%[1]s", "source_location_name": "first", "http_package_configurations": [{"path": "/", "source_group": "default", "type": "HLS"}]}`
+	plan := `{"name": "%[1]s", "source_location_name": "first", "http_package_configurations": [
+		{"path": "/", "source_group": "default", "type": "HLS"},
+		{"path": "/other", "source_group": "default", "type": "HLS"}
+	]}`
+	for name, r := range map[string]resource.Resource{
+		"vod":  &resourceVodSource{client: fake},
+		"live": &resourceLiveSource{client: fake},
+	} {
+		schemaResp := resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		priorState, err := tftypes.ValueFromJSON([]byte(fmt.Sprintf(state, name)), schemaResp.Schema.Type().TerraformType(ctx))
+		if err != nil {
+			t.Fatal(err)
+		}
+		planned, err := tftypes.ValueFromJSON([]byte(fmt.Sprintf(plan, name)), schemaResp.Schema.Type().TerraformType(ctx))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := resource.UpdateRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: planned},
+			Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned},
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: priorState},
+		}
+		resp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: priorState}}
+		r.Update(ctx, req, &resp)
+
+		if resp.Diagnostics.ErrorsCount() != 1 {
+			t.Fatalf("expected one error updating the %s source, got %v", name, resp.Diagnostics)
+		}
+		d, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+		if !ok || !d.Path().Equal(path.Root("http_package_configurations")) {
+			t.Errorf("expected the error to be reported on http_package_configurations, got %v", resp.Diagnostics.Errors()[0])
+		}
+		if !resp.State.Raw.Equal(priorState) {
+			t.Errorf("expected the state of the %s source not to change", name)
+		}
+	}
+}
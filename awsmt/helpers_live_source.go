@@ -1,9 +1,10 @@
 package awsmt
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
+	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
 )
 
 func liveSourceInput(plan liveSourceModel) mediatailor.CreateLiveSourceInput {
@@ -40,13 +41,13 @@ func readLiveSourceToPlan(plan liveSourceModel, liveSource mediatailor.CreateLiv
 	}
 
 	if liveSource.CreationTime != nil {
-		plan.CreationTime = types.StringValue((aws.TimeValue(liveSource.CreationTime)).String())
+		plan.CreationTime = timestampValue(liveSource.CreationTime)
 	}
 
 	plan.HttpPackageConfigurations = readHttpPackageConfigurations(liveSource.HttpPackageConfigurations)
 
 	if liveSource.LastModifiedTime != nil {
-		plan.LastModifiedTime = types.StringValue((aws.TimeValue(liveSource.LastModifiedTime)).String())
+		plan.LastModifiedTime = timestampValue(liveSource.LastModifiedTime)
 	}
 
 	if liveSource.LiveSourceName != nil {
@@ -81,3 +82,49 @@ func liveSourceUpdateInput(plan liveSourceModel) mediatailor.UpdateLiveSourceInp
 
 	return input
 }
+
+// getLiveSourceNamesFromSelectors resolves the source location name and name of the live source a data source refers
+// to, using whichever of the name, arn or tags selectors is set.
+func getLiveSourceNamesFromSelectors(ctx context.Context, client mediaTailorClient, data liveSourceDataSourceModel) (*string, *string, error) {
+	if !data.Arn.IsNull() {
+		names, err := resolveNamesFromArn(ctx, client, data.Arn.ValueString(), "liveSource")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &names[0], &names[1], nil
+	}
+	if data.Tags != nil {
+		return findLiveSourceByTags(ctx, client, data.SourceLocationName, data.Tags)
+	}
+	return data.SourceLocationName, data.Name, nil
+}
+
+func findLiveSourceByTags(ctx context.Context, client mediaTailorClient, sourceLocationName *string, tags map[string]*string) (*string, *string, error) {
+	if len(tags) == 0 {
+		return nil, nil, errEmptyTagFilter
+	}
+	sourceLocationNames, err := listSourceLocationNames(ctx, client, sourceLocationName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var matches []string
+	for _, name := range sourceLocationNames {
+		err := client.ListLiveSourcesPagesWithContext(ctx, &mediatailor.ListLiveSourcesInput{SourceLocationName: name}, func(page *mediatailor.ListLiveSourcesOutput, _ bool) bool {
+			for _, liveSource := range page.Items {
+				if tagsMatch(liveSource.Tags, tags) {
+					matches = append(matches, *liveSource.SourceLocationName+","+*liveSource.LiveSourceName)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	id, err := singleMatch("live source", matches)
+	if err != nil {
+		return nil, nil, err
+	}
+	idParts := strings.SplitN(id, ",", 2)
+	return &idParts[0], &idParts[1], nil
+}
@@ -0,0 +1,102 @@
+package awsmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// logSubsystem is the tflog subsystem of the MediaTailor requests. Its level is set with
+	// TF_LOG_PROVIDER_AWSMT_MEDIATAILOR.
+	logSubsystem         = "mediatailor"
+	logSubsystemLevelEnv = "TF_LOG_PROVIDER_AWSMT_MEDIATAILOR"
+	maskedValue          = "***"
+)
+
+var (
+	// urlQueryPattern matches the query of a URL, which may carry tokens, for example in ADS URLs.
+	urlQueryPattern = regexp.MustCompile(`(https?://[^\s"'?]*\?)([^\s"'#]*)`)
+	// secretFieldPattern matches the fields of a JSON document that reference the access token of a source location.
+	secretFieldPattern = regexp.MustCompile(`("(?:SecretArn|SecretStringKey|secret_arn|secret_string_key)"\s*:\s*)"[^"]*"`)
+	// dynamicVariablePattern matches the MediaTailor dynamic variables, which are placeholders rather than secrets.
+	dynamicVariablePattern = regexp.MustCompile(`^\[[\w.\-]+]$`)
+)
+
+// logRequestHandler logs every MediaTailor request once it completed, whether it succeeded or not.
+var logRequestHandler = request.NamedHandler{Name: "awsmt.LogRequest", Fn: logRequest}
+
+func logRequest(r *request.Request) {
+	ctx := tflog.NewSubsystem(r.Context(), logSubsystem, tflog.WithLevelFromEnv(logSubsystemLevelEnv))
+	fields := map[string]interface{}{
+		"operation":      r.Operation.Name,
+		"duration_ms":    time.Since(r.Time).Milliseconds(),
+		"retry_count":    r.RetryCount,
+		"aws_request_id": r.RequestID,
+	}
+	if r.HTTPResponse != nil && r.HTTPResponse.StatusCode != 0 {
+		fields["http_status"] = r.HTTPResponse.StatusCode
+	}
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "MediaTailor request parameters", map[string]interface{}{
+		"operation":  r.Operation.Name,
+		"parameters": redactedJSON(r.Params),
+	})
+	if r.Error != nil {
+		fields["error"] = redactSecrets(r.Error.Error())
+		tflog.SubsystemWarn(ctx, logSubsystem, "MediaTailor request failed", fields)
+		return
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "MediaTailor request completed", fields)
+}
+
+// redactSecrets masks the query parameter values of URLs, except for dynamic variables, and the secret references of
+// access token configurations.
+func redactSecrets(value string) string {
+	value = urlQueryPattern.ReplaceAllStringFunc(value, func(match string) string {
+		groups := urlQueryPattern.FindStringSubmatch(match)
+		parameters := strings.Split(groups[2], "&")
+		for i, parameter := range parameters {
+			key, parameterValue, found := strings.Cut(parameter, "=")
+			if found && parameterValue != "" && !dynamicVariablePattern.MatchString(parameterValue) {
+				parameters[i] = key + "=" + maskedValue
+			}
+		}
+		return groups[1] + strings.Join(parameters, "&")
+	})
+	return secretFieldPattern.ReplaceAllString(value, `${1}"`+maskedValue+`"`)
+}
+
+// redactedJSON returns the value as JSON with its secrets masked.
+func redactedJSON(value interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	// URLs are kept readable, so that their query can be masked.
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprintf("<%s>", err)
+	}
+	return redactSecrets(strings.TrimSpace(buf.String()))
+}
+
+// apiErrorDetail returns the detail of a diagnostic for an error returned by the MediaTailor API. Secrets are masked,
+// and the HTTP status and the AWS request ID of a failed request are given on a line of their own, so that the
+// request can be looked up by AWS support.
+func apiErrorDetail(err error) string {
+	var failure awserr.RequestFailure
+	if !errors.As(err, &failure) {
+		return redactSecrets(err.Error())
+	}
+	detail := failure.Code() + ": " + failure.Message()
+	if wrapped := err.Error(); wrapped != failure.Error() {
+		detail = strings.Replace(wrapped, failure.Error(), detail, 1)
+	}
+	return fmt.Sprintf("%s\n\nHTTP status: %d, AWS request ID: %s", redactSecrets(detail), failure.StatusCode(), failure.RequestID())
+}
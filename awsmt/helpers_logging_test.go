@@ -0,0 +1,97 @@
+package awsmt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"strings"
+	"testing"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	tests := map[string]string{
+		"https://ads.example.com/vast?token=abc123&id=[session.id]&n=":                                          "https://ads.example.com/vast?token=***&id=[session.id]&n=",
+		`{"AdDecisionServerUrl":"https://ads.example.com/?key=secret"}`:                                         `{"AdDecisionServerUrl":"https://ads.example.com/?key=***"}`,
+		`{"SecretArn":"arn:aws:secretsmanager:eu-central-1:123456789012:secret:token","SecretStringKey":"key"}`: `{"SecretArn":"***","SecretStringKey":"***"}`,
+		"https://example.com/content/index.m3u8":                                                                "https://example.com/content/index.m3u8",
+	}
+	for value, expected := range tests {
+		if got := redactSecrets(value); got != expected {
+			t.Errorf("expected %s to be redacted to %s, got %s", value, expected, got)
+		}
+	}
+}
+
+func TestApiErrorDetail(t *testing.T) {
+	_, err := newFakeMediaTailor("eu-central-1", "123456789012").DescribeChannelWithContext(context.Background(), &mediatailor.DescribeChannelInput{ChannelName: aws.String("test")})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	expected := "NotFoundException: Channel test not found\n\nHTTP status: 404, AWS request ID: 00000000-0000-0000-0000-000000000001"
+	if got := apiErrorDetail(err); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	expected = "error while describing: " + expected
+	if got := apiErrorDetail(fmt.Errorf("error while describing: %w", err)); got != expected {
+		t.Errorf("expected the wrapped error to keep its context, got %q", got)
+	}
+	if got := apiErrorDetail(errors.New("https://ads.example.com/?token=abc is invalid")); got != "https://ads.example.com/?token=*** is invalid" {
+		t.Errorf("expected other errors to be redacted, got %q", got)
+	}
+}
+
+func TestLogRequest(t *testing.T) {
+	t.Setenv(logSubsystemLevelEnv, "TRACE")
+	_, client := newEmulatorClient(t)
+	client.Handlers.Complete.PushBackNamed(logRequestHandler)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	if _, err := client.PutPlaybackConfigurationWithContext(ctx, &mediatailor.PutPlaybackConfigurationInput{
+		Name:                  aws.String("test"),
+		AdDecisionServerUrl:   aws.String("https://ads.example.com/vast?token=abc123"),
+		VideoContentSourceUrl: aws.String("https://example.com/content"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = client.CreateSourceLocationWithContext(ctx, &mediatailor.CreateSourceLocationInput{
+		SourceLocationName: aws.String("test"),
+		HttpConfiguration:  &mediatailor.HttpConfiguration{BaseUrl: aws.String("ftp://example.com")},
+		AccessConfiguration: &mediatailor.AccessConfiguration{
+			AccessType: aws.String(mediatailor.AccessTypeSecretsManagerAccessToken),
+			SecretsManagerAccessTokenConfiguration: &mediatailor.SecretsManagerAccessTokenConfiguration{
+				HeaderName:      aws.String("Authorization"),
+				SecretArn:       aws.String("arn:aws:secretsmanager:eu-central-1:123456789012:secret:token"),
+				SecretStringKey: aws.String("token"),
+			},
+		},
+	})
+
+	if strings.Contains(output.String(), "abc123") || strings.Contains(output.String(), "secret:token") {
+		t.Errorf("expected secrets to be masked, got %s", output.String())
+	}
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var completed, failed map[string]interface{}
+	for _, entry := range entries {
+		switch entry["@message"] {
+		case "MediaTailor request completed":
+			completed = entry
+		case "MediaTailor request failed":
+			failed = entry
+		}
+	}
+	if completed == nil || completed["operation"] != "PutPlaybackConfiguration" || completed["http_status"] != float64(200) || completed["aws_request_id"] == "" {
+		t.Errorf("unexpected log entry for a successful request: %v", completed)
+	}
+	if failed == nil || failed["operation"] != "CreateSourceLocation" || failed["http_status"] != float64(400) || failed["@module"] != "provider."+logSubsystem {
+		t.Errorf("unexpected log entry for a failed request: %v", failed)
+	}
+}
@@ -1,7 +1,9 @@
 package awsmt
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -161,8 +163,12 @@ func readPlaybackConfigToPlan(plan playbackConfigurationModel, playbackConfigura
 	}
 
 	// HLS CONFIGURATION
+	plan.HlsConfiguration = types.ObjectNull(hlsConfigurationAttributeTypes)
 	if playbackConfiguration.HlsConfiguration != nil && playbackConfiguration.HlsConfiguration.ManifestEndpointPrefix != nil {
 		plan.HlsConfigurationManifestEndpointPrefix = types.StringValue(*playbackConfiguration.HlsConfiguration.ManifestEndpointPrefix)
+		plan.HlsConfiguration = types.ObjectValueMust(hlsConfigurationAttributeTypes, map[string]attr.Value{
+			"manifest_endpoint_prefix": plan.HlsConfigurationManifestEndpointPrefix,
+		})
 	}
 
 	// LOG CONFIGURATION
@@ -171,6 +177,9 @@ func readPlaybackConfigToPlan(plan playbackConfigurationModel, playbackConfigura
 	} else {
 		plan.LogConfigurationPercentEnabled = types.Int64Value(0)
 	}
+	plan.LogConfiguration = types.ObjectValueMust(logConfigurationAttributeTypes, map[string]attr.Value{
+		"percent_enabled": plan.LogConfigurationPercentEnabled,
+	})
 
 	// LIVE PRE ROLL CONFIGURATION
 	if playbackConfiguration.LivePreRollConfiguration != nil && (playbackConfiguration.LivePreRollConfiguration.AdDecisionServerUrl != nil || playbackConfiguration.LivePreRollConfiguration.MaxDurationSeconds != nil) {
@@ -298,3 +307,42 @@ func readManifestProcessingRules(plan playbackConfigurationModel, playbackConfig
 	}
 	return plan
 }
+
+// getPlaybackConfigurationNameFromSelectors resolves the name of the playback configuration a data source refers
+// to, using whichever of the name, playback_configuration_arn or tags selectors is set.
+func getPlaybackConfigurationNameFromSelectors(ctx context.Context, client mediaTailorClient, data playbackConfigurationDataSourceModel) (*string, error) {
+	if !data.PlaybackConfigurationArn.IsNull() {
+		names, err := resolveNamesFromArn(ctx, client, data.PlaybackConfigurationArn.ValueString(), "playbackConfiguration")
+		if err != nil {
+			return nil, err
+		}
+		return &names[0], nil
+	}
+	if data.Tags != nil {
+		return findPlaybackConfigurationByTags(ctx, client, data.Tags)
+	}
+	return data.Name, nil
+}
+
+func findPlaybackConfigurationByTags(ctx context.Context, client mediaTailorClient, tags map[string]*string) (*string, error) {
+	if len(tags) == 0 {
+		return nil, errEmptyTagFilter
+	}
+	var matches []string
+	err := client.ListPlaybackConfigurationsPagesWithContext(ctx, &mediatailor.ListPlaybackConfigurationsInput{}, func(page *mediatailor.ListPlaybackConfigurationsOutput, _ bool) bool {
+		for _, playbackConfiguration := range page.Items {
+			if tagsMatch(playbackConfiguration.Tags, tags) {
+				matches = append(matches, *playbackConfiguration.Name)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	name, err := singleMatch("playback configuration", matches)
+	if err != nil {
+		return nil, err
+	}
+	return &name, nil
+}
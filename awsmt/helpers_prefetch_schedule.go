@@ -0,0 +1,74 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func listPrefetchSchedules(ctx context.Context, client mediaTailorClient, playbackConfigurationName *string, streamId *string) ([]*mediatailor.PrefetchSchedule, error) {
+	var prefetchSchedules []*mediatailor.PrefetchSchedule
+	input := &mediatailor.ListPrefetchSchedulesInput{PlaybackConfigurationName: playbackConfigurationName, StreamId: streamId}
+	err := client.ListPrefetchSchedulesPagesWithContext(ctx, input, func(page *mediatailor.ListPrefetchSchedulesOutput, _ bool) bool {
+		prefetchSchedules = append(prefetchSchedules, page.Items...)
+		return true
+	})
+	return prefetchSchedules, err
+}
+
+func readPrefetchSchedulesToState(state prefetchSchedulesModel, prefetchSchedules []*mediatailor.PrefetchSchedule) prefetchSchedulesModel {
+	id := *state.PlaybackConfigurationName
+	if state.StreamId != nil {
+		id += "," + *state.StreamId
+	}
+	state.ID = types.StringValue(id)
+
+	state.PrefetchSchedules = []prefetchScheduleModel{}
+	for _, prefetchSchedule := range prefetchSchedules {
+		temp := prefetchScheduleModel{
+			Arn:                       prefetchSchedule.Arn,
+			Name:                      prefetchSchedule.Name,
+			PlaybackConfigurationName: prefetchSchedule.PlaybackConfigurationName,
+			StreamId:                  prefetchSchedule.StreamId,
+		}
+		if prefetchSchedule.Consumption != nil {
+			temp.Consumption = readPrefetchConsumption(prefetchSchedule.Consumption)
+		}
+		if prefetchSchedule.Retrieval != nil {
+			temp.Retrieval = readPrefetchRetrieval(prefetchSchedule.Retrieval)
+		}
+		state.PrefetchSchedules = append(state.PrefetchSchedules, temp)
+	}
+	return state
+}
+
+func readPrefetchConsumption(consumption *mediatailor.PrefetchConsumption) *prefetchConsumptionModel {
+	model := &prefetchConsumptionModel{}
+	for _, criteria := range consumption.AvailMatchingCriteria {
+		model.AvailMatchingCriteria = append(model.AvailMatchingCriteria, availMatchingCriteriaModel{
+			DynamicVariable: criteria.DynamicVariable,
+			Operator:        criteria.Operator,
+		})
+	}
+	if consumption.EndTime != nil {
+		model.EndTime = timestampValue(consumption.EndTime)
+	}
+	if consumption.StartTime != nil {
+		model.StartTime = timestampValue(consumption.StartTime)
+	}
+	return model
+}
+
+func readPrefetchRetrieval(retrieval *mediatailor.PrefetchRetrieval) *prefetchRetrievalModel {
+	model := &prefetchRetrievalModel{}
+	if len(retrieval.DynamicVariables) > 0 {
+		model.DynamicVariables = retrieval.DynamicVariables
+	}
+	if retrieval.EndTime != nil {
+		model.EndTime = timestampValue(retrieval.EndTime)
+	}
+	if retrieval.StartTime != nil {
+		model.StartTime = timestampValue(retrieval.StartTime)
+	}
+	return model
+}
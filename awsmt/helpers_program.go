@@ -0,0 +1,83 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func readProgramToState(state programModel, program mediatailor.DescribeProgramOutput) programModel {
+	state.ID = types.StringValue(*program.ChannelName + "," + *program.ProgramName)
+
+	if program.Arn != nil {
+		state.Arn = types.StringValue(*program.Arn)
+	}
+
+	state.AdBreaks = readAdBreaks(program.AdBreaks)
+
+	state.ChannelName = program.ChannelName
+
+	if program.ClipRange != nil {
+		state.ClipRange = &clipRangeModel{EndOffsetMillis: program.ClipRange.EndOffsetMillis}
+	}
+
+	if program.CreationTime != nil {
+		state.CreationTime = timestampValue(program.CreationTime)
+	}
+
+	state.DurationMillis = program.DurationMillis
+	state.LiveSourceName = program.LiveSourceName
+	state.ProgramName = program.ProgramName
+
+	if program.ScheduledStartTime != nil {
+		state.ScheduledStartTime = timestampValue(program.ScheduledStartTime)
+	}
+
+	state.SourceLocationName = program.SourceLocationName
+	state.VodSourceName = program.VodSourceName
+
+	return state
+}
+
+func readAdBreaks(adBreaks []*mediatailor.AdBreak) []adBreakModel {
+	var adBreaksRead []adBreakModel
+	for _, adBreak := range adBreaks {
+		temp := adBreakModel{
+			MessageType:  adBreak.MessageType,
+			OffsetMillis: adBreak.OffsetMillis,
+		}
+		for _, metadata := range adBreak.AdBreakMetadata {
+			temp.AdBreakMetadata = append(temp.AdBreakMetadata, keyValuePairModel{Key: metadata.Key, Value: metadata.Value})
+		}
+		if adBreak.Slate != nil {
+			temp.Slate = &fillerSlateModel{
+				SourceLocationName: adBreak.Slate.SourceLocationName,
+				VodSourceName:      adBreak.Slate.VodSourceName,
+			}
+		}
+		if adBreak.SpliceInsertMessage != nil {
+			temp.SpliceInsertMessage = &spliceInsertMessageModel{
+				AvailNum:        adBreak.SpliceInsertMessage.AvailNum,
+				AvailsExpected:  adBreak.SpliceInsertMessage.AvailsExpected,
+				SpliceEventId:   adBreak.SpliceInsertMessage.SpliceEventId,
+				UniqueProgramId: adBreak.SpliceInsertMessage.UniqueProgramId,
+			}
+		}
+		if adBreak.TimeSignalMessage != nil {
+			temp.TimeSignalMessage = &timeSignalMessageModel{}
+			for _, descriptor := range adBreak.TimeSignalMessage.SegmentationDescriptors {
+				temp.TimeSignalMessage.SegmentationDescriptors = append(temp.TimeSignalMessage.SegmentationDescriptors, segmentationDescriptorModel{
+					SegmentNum:           descriptor.SegmentNum,
+					SegmentationEventId:  descriptor.SegmentationEventId,
+					SegmentationTypeId:   descriptor.SegmentationTypeId,
+					SegmentationUpid:     descriptor.SegmentationUpid,
+					SegmentationUpidType: descriptor.SegmentationUpidType,
+					SegmentsExpected:     descriptor.SegmentsExpected,
+					SubSegmentNum:        descriptor.SubSegmentNum,
+					SubSegmentsExpected:  descriptor.SubSegmentsExpected,
+				})
+			}
+		}
+		adBreaksRead = append(adBreaksRead, temp)
+	}
+	return adBreaksRead
+}
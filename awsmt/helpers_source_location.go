@@ -1,7 +1,7 @@
 package awsmt
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
+	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -134,7 +134,7 @@ func readSourceLocationToPlan(plan sourceLocationModel, sourceLocation mediatail
 		plan.Arn = types.StringValue(*sourceLocation.Arn)
 	}
 	if sourceLocation.CreationTime != nil {
-		plan.CreationTime = types.StringValue((aws.TimeValue(sourceLocation.CreationTime)).String())
+		plan.CreationTime = timestampValue(sourceLocation.CreationTime)
 	}
 	if sourceLocation.DefaultSegmentDeliveryConfiguration != nil {
 		plan = readDefaultSegmentDeliveryConfiguration(plan, sourceLocation)
@@ -143,7 +143,7 @@ func readSourceLocationToPlan(plan sourceLocationModel, sourceLocation mediatail
 		plan = readHttpConfiguration(plan, sourceLocation)
 	}
 	if sourceLocation.LastModifiedTime != nil {
-		plan.LastModifiedTime = types.StringValue((aws.TimeValue(sourceLocation.LastModifiedTime)).String())
+		plan.LastModifiedTime = timestampValue(sourceLocation.LastModifiedTime)
 	}
 	if sourceLocation.SegmentDeliveryConfigurations != nil && len(sourceLocation.SegmentDeliveryConfigurations) > 0 {
 		plan = readSegmentDeliveryConfigurations(plan, sourceLocation)
@@ -224,28 +224,28 @@ func readHttpConfiguration(plan sourceLocationModel, sourceLocation mediatailor.
 	return plan
 }
 
-func deleteSourceLocation(client *mediatailor.MediaTailor, name *string) error {
-	vodSourcesList, err := client.ListVodSources(&mediatailor.ListVodSourcesInput{SourceLocationName: name})
+func deleteSourceLocation(ctx context.Context, client mediaTailorClient, name *string) error {
+	vodSourcesList, err := client.ListVodSourcesWithContext(ctx, &mediatailor.ListVodSourcesInput{SourceLocationName: name})
 	if err != nil {
 		return err
 	}
 	for _, vodSource := range vodSourcesList.Items {
-		if _, err := client.DeleteVodSource(&mediatailor.DeleteVodSourceInput{VodSourceName: vodSource.VodSourceName, SourceLocationName: name}); err != nil {
+		if _, err := client.DeleteVodSourceWithContext(ctx, &mediatailor.DeleteVodSourceInput{VodSourceName: vodSource.VodSourceName, SourceLocationName: name}); err != nil {
 			return err
 		}
 	}
-	liveSourcesList, err := client.ListLiveSources(&mediatailor.ListLiveSourcesInput{SourceLocationName: name})
+	liveSourcesList, err := client.ListLiveSourcesWithContext(ctx, &mediatailor.ListLiveSourcesInput{SourceLocationName: name})
 	if err != nil {
 
 		return err
 	}
 	for _, liveSource := range liveSourcesList.Items {
-		if _, err := client.DeleteLiveSource(&mediatailor.DeleteLiveSourceInput{LiveSourceName: liveSource.LiveSourceName, SourceLocationName: name}); err != nil {
+		if _, err := client.DeleteLiveSourceWithContext(ctx, &mediatailor.DeleteLiveSourceInput{LiveSourceName: liveSource.LiveSourceName, SourceLocationName: name}); err != nil {
 
 			return err
 		}
 	}
-	_, err = client.DeleteSourceLocation(&mediatailor.DeleteSourceLocationInput{SourceLocationName: name})
+	_, err = client.DeleteSourceLocationWithContext(ctx, &mediatailor.DeleteSourceLocationInput{SourceLocationName: name})
 	if err != nil {
 
 		return err
@@ -253,3 +253,58 @@ func deleteSourceLocation(client *mediatailor.MediaTailor, name *string) error {
 
 	return nil
 }
+
+// getSourceLocationNameFromSelectors resolves the name of the source location a data source refers to, using
+// whichever of the name, arn or tags selectors is set.
+func getSourceLocationNameFromSelectors(ctx context.Context, client mediaTailorClient, data sourceLocationDataSourceModel) (*string, error) {
+	if !data.Arn.IsNull() {
+		names, err := resolveNamesFromArn(ctx, client, data.Arn.ValueString(), "sourceLocation")
+		if err != nil {
+			return nil, err
+		}
+		return &names[0], nil
+	}
+	if data.Tags != nil {
+		return findSourceLocationByTags(ctx, client, data.Tags)
+	}
+	return data.Name, nil
+}
+
+func findSourceLocationByTags(ctx context.Context, client mediaTailorClient, tags map[string]*string) (*string, error) {
+	if len(tags) == 0 {
+		return nil, errEmptyTagFilter
+	}
+	var matches []string
+	err := client.ListSourceLocationsPagesWithContext(ctx, &mediatailor.ListSourceLocationsInput{}, func(page *mediatailor.ListSourceLocationsOutput, _ bool) bool {
+		for _, sourceLocation := range page.Items {
+			if tagsMatch(sourceLocation.Tags, tags) {
+				matches = append(matches, *sourceLocation.SourceLocationName)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	name, err := singleMatch("source location", matches)
+	if err != nil {
+		return nil, err
+	}
+	return &name, nil
+}
+
+// listSourceLocationNames returns the given source location name, or the names of all source locations if it is nil.
+// It is used to scope searches for VOD and live sources.
+func listSourceLocationNames(ctx context.Context, client mediaTailorClient, sourceLocationName *string) ([]*string, error) {
+	if sourceLocationName != nil {
+		return []*string{sourceLocationName}, nil
+	}
+	var names []*string
+	err := client.ListSourceLocationsPagesWithContext(ctx, &mediatailor.ListSourceLocationsInput{}, func(page *mediatailor.ListSourceLocationsOutput, _ bool) bool {
+		for _, sourceLocation := range page.Items {
+			names = append(names, sourceLocation.SourceLocationName)
+		}
+		return true
+	})
+	return names, err
+}
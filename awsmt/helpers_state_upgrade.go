@@ -0,0 +1,40 @@
+package awsmt
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// upgradeStateJSON returns a state upgrader working on the raw JSON of the prior state, so that the prior schema
+// does not have to be kept around. The upgrades are applied in order, so an upgrader for an old version can chain the
+// upgrades of every later version. Attributes the upgrades do not set are null in the upgraded state.
+func upgradeStateJSON(upgrades ...func(state map[string]interface{}) error) func(context.Context, resource.UpgradeStateRequest, *resource.UpgradeStateResponse) {
+	return func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+		if req.RawState == nil || req.RawState.JSON == nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The prior state is not stored as JSON.")
+			return
+		}
+
+		var state map[string]interface{}
+		if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The prior state could not be parsed: "+err.Error())
+			return
+		}
+
+		for _, upgrade := range upgrades {
+			if err := upgrade(state); err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+				return
+			}
+		}
+
+		upgraded, err := json.Marshal(state)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+			return
+		}
+		resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+	}
+}
@@ -0,0 +1,112 @@
+package awsmt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// upgradeRecordedState runs the state upgrader of the given version on a state recorded in testdata/state and
+// returns the upgraded state, after checking that it matches the current schema of the resource.
+func upgradeRecordedState(t *testing.T, r resource.Resource, version int64, file string) map[string]interface{} {
+	t.Helper()
+	ctx := context.Background()
+
+	raw, err := os.ReadFile("testdata/state/" + file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgrader, ok := r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no state upgrader for version %d", version)
+	}
+	resp := resource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if _, err := tftypes.ValueFromJSON(resp.DynamicValue.JSON, schemaResp.Schema.Type().TerraformType(ctx)); err != nil {
+		t.Fatalf("upgraded state does not match the schema: %s", err)
+	}
+
+	var upgraded map[string]interface{}
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+		t.Fatal(err)
+	}
+	return upgraded
+}
+
+func TestUpgradePlaybackConfigurationStateV0(t *testing.T) {
+	state := upgradeRecordedState(t, ResourcePlaybackConfiguration(), 0, "playback_configuration_v0.json")
+
+	expectedHls := map[string]interface{}{"manifest_endpoint_prefix": state["hls_configuration_manifest_endpoint_prefix"]}
+	if !reflect.DeepEqual(state["hls_configuration"], expectedHls) {
+		t.Errorf("expected hls_configuration %v, got %v", expectedHls, state["hls_configuration"])
+	}
+	expectedLog := map[string]interface{}{"percent_enabled": float64(0)}
+	if !reflect.DeepEqual(state["log_configuration"], expectedLog) {
+		t.Errorf("expected log_configuration %v, got %v", expectedLog, state["log_configuration"])
+	}
+}
+
+// TestRecordedStates checks every resource of the provider: each prior schema version needs an upgrader and a
+// recorded state it can upgrade, and the recorded state of the current version must still match the schema.
+func TestRecordedStates(t *testing.T) {
+	ctx := context.Background()
+	for _, newResource := range New().Resources(ctx) {
+		r := newResource()
+
+		metadata := resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "awsmt"}, &metadata)
+		name := strings.TrimPrefix(metadata.TypeName, "awsmt_")
+
+		schemaResp := resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		version := schemaResp.Schema.Version
+
+		t.Run(name, func(t *testing.T) {
+			for v := int64(0); v < version; v++ {
+				upgradeRecordedState(t, r, v, fmt.Sprintf("%s_v%d.json", name, v))
+			}
+
+			raw, err := os.ReadFile(fmt.Sprintf("testdata/state/%s_v%d.json", name, version))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tftypes.ValueFromJSON(raw, schemaResp.Schema.Type().TerraformType(ctx)); err != nil {
+				t.Errorf("recorded state of version %d does not match the schema, bump the version and add an upgrader: %s", version, err)
+			}
+		})
+	}
+}
+
+func TestUpgradeChannelStateV0(t *testing.T) {
+	state := upgradeRecordedState(t, ResourceChannel(), 0, "channel_v0.json")
+	if state["enforce_channel_state"] != true {
+		t.Errorf("expected enforce_channel_state to be true, got %v", state["enforce_channel_state"])
+	}
+	if state["creation_time"] != "2023-11-08T10:15:42.123Z" {
+		t.Errorf("expected creation_time to be RFC 3339, got %v", state["creation_time"])
+	}
+}
+
+func TestUpgradeChannelStateV1(t *testing.T) {
+	state := upgradeRecordedState(t, ResourceChannel(), 1, "channel_v1.json")
+	if state["creation_time"] != "2023-11-08T10:15:42.123Z" {
+		t.Errorf("expected creation_time to be RFC 3339, got %v", state["creation_time"])
+	}
+	if state["last_modified_time"] != "2023-11-08T10:15:43.456Z" {
+		t.Errorf("expected last_modified_time to be RFC 3339, got %v", state["last_modified_time"])
+	}
+}
@@ -0,0 +1,28 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"time"
+)
+
+// The timeouts of the CRUD operations when the timeouts attribute of a resource does not set one. MediaTailor
+// operations are synchronous, so the timeouts only have to cover the retries of the SDK.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// withTimeout derives a context from the request context that expires after the timeout configured for the
+// operation, or after the fallback if none is configured. The context is also cancelled when Terraform cancels the
+// request, which aborts the SDK call and its retries.
+func withTimeout(ctx context.Context, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), fallback time.Duration) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	duration, diags := timeout(ctx, fallback)
+	if diags.HasError() {
+		return ctx, func() {}, diags
+	}
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	return ctx, cancel, diags
+}
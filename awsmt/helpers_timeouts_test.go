@@ -0,0 +1,85 @@
+package awsmt
+
+import (
+	"context"
+	datasourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout(t *testing.T) {
+	attributeTypes := map[string]attr.Type{"create": types.StringType}
+
+	configured := timeouts.Value{Object: types.ObjectValueMust(attributeTypes, map[string]attr.Value{"create": types.StringValue("1m")})}
+	ctx, cancel, diags := withTimeout(context.Background(), configured.Create, time.Hour)
+	defer cancel()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("expected a deadline within a minute, got %v", deadline)
+	}
+
+	unset := timeouts.Value{Object: types.ObjectNull(attributeTypes)}
+	ctx, cancel, diags = withTimeout(context.Background(), unset.Create, time.Hour)
+	defer cancel()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) <= time.Minute {
+		t.Errorf("expected the fallback deadline in an hour, got %v", deadline)
+	}
+
+	invalid := timeouts.Value{Object: types.ObjectValueMust(attributeTypes, map[string]attr.Value{"create": types.StringValue("soon")})}
+	if _, cancel, diags = withTimeout(context.Background(), invalid.Create, time.Hour); !diags.HasError() {
+		t.Error("expected an error for an invalid duration")
+	}
+	cancel()
+}
+
+func TestDataSourceTimeouts(t *testing.T) {
+	ctx := context.Background()
+	var (
+		channel               channelDataSourceModel
+		liveSource            liveSourceDataSourceModel
+		playbackConfiguration playbackConfigurationDataSourceModel
+		prefetchSchedules     prefetchSchedulesModel
+		program               programModel
+		sourceLocation        sourceLocationDataSourceModel
+		vodSource             vodSourceDataSourceModel
+	)
+	for _, c := range []struct {
+		dataSource datasource.DataSource
+		model      interface{}
+		timeouts   *datasourcetimeouts.Value
+	}{
+		{DataSourceChannel(), &channel, &channel.Timeouts},
+		{DataSourceLiveSource(), &liveSource, &liveSource.Timeouts},
+		{DataSourcePlaybackConfiguration(), &playbackConfiguration, &playbackConfiguration.Timeouts},
+		{DataSourcePrefetchSchedules(), &prefetchSchedules, &prefetchSchedules.Timeouts},
+		{DataSourceProgram(), &program, &program.Timeouts},
+		{DataSourceSourceLocation(), &sourceLocation, &sourceLocation.Timeouts},
+		{DataSourceVodSource(), &vodSource, &vodSource.Timeouts},
+	} {
+		schemaResp := datasource.SchemaResponse{}
+		c.dataSource.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+		raw, err := tftypes.ValueFromJSON([]byte(`{"timeouts": {"read": "1m"}}`), schemaResp.Schema.Type().TerraformType(ctx))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		config := tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}
+		if diags := config.Get(ctx, c.model); diags.HasError() {
+			t.Fatalf("unexpected error reading the configuration into %T: %v", c.model, diags)
+		}
+		if read, diags := c.timeouts.Read(ctx, time.Hour); diags.HasError() || read != time.Minute {
+			t.Errorf("expected the read timeout of %T to be a minute, got %s", c.model, read)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"strings"
+)
+
+// @ADR
+// Context: Slow applies could not be attributed to the resource operations and MediaTailor requests they consist of.
+// Decision: We decided to trace every CRUD operation of a managed resource with OpenTelemetry, with a child span for
+// every MediaTailor request. Tracing is configured with the standard OTEL_* environment variables only, and is
+// enabled by setting an OTLP endpoint.
+// Consequences: Spans are started with the global tracer provider, so they are dropped unless StartTracing installed
+// an exporter. Request spans are started by SDK handlers, and therefore only exist for clients created in Configure.
+
+const (
+	tracerName         = "terraform-provider-mediatailor/awsmt"
+	tracingServiceName = "terraform-provider-awsmt"
+
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+)
+
+var (
+	attributeResourceType = attribute.Key("awsmt.resource.type")
+	attributeResourceName = attribute.Key("awsmt.resource.name")
+	attributeOperation    = attribute.Key("awsmt.operation")
+	attributeOutcome      = attribute.Key("awsmt.outcome")
+	attributeRetryCount   = attribute.Key("awsmt.retry_count")
+	attributeRequestID    = attribute.Key("aws.request_id")
+)
+
+// StartTracing exports spans to the OTLP collector configured with the standard OTEL_* environment variables. Tracing
+// stays disabled unless OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set. The returned
+// function flushes the pending spans and stops the exporter.
+func StartTracing(ctx context.Context) (func(context.Context) error, error) {
+	if !tracingEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newTraceExporter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the OTLP trace exporter: %w", err)
+	}
+	// Resource attributes from the environment, such as OTEL_SERVICE_NAME, override the default service name.
+	res, err := sdkresource.New(ctx,
+		sdkresource.WithAttributes(semconv.ServiceName(tracingServiceName)),
+		sdkresource.WithTelemetrySDK(),
+		sdkresource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the OpenTelemetry resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func tracingEnabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") || os.Getenv("OTEL_TRACES_EXPORTER") == "none" {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// newTraceExporter creates an OTLP exporter for the protocol selected by the environment, which defaults to
+// http/protobuf. The exporters read their endpoint, headers and TLS settings from the environment themselves.
+func newTraceExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	if exporter := os.Getenv("OTEL_TRACES_EXPORTER"); exporter != "" && exporter != "otlp" {
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q, only otlp is supported", exporter)
+	}
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	switch protocol {
+	case "", "http/protobuf":
+		return otlptracehttp.New(ctx)
+	case "grpc":
+		return otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q, expected grpc or http/protobuf", protocol)
+	}
+}
+
+// startResourceSpan starts the span of a CRUD operation on a managed resource. End it with endResourceSpan.
+func startResourceSpan(ctx context.Context, resourceType string, operation string, name *string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, resourceType+"."+operation, trace.WithAttributes(
+		attributeResourceType.String(resourceType),
+		attributeResourceName.String(aws.StringValue(name)),
+		attributeOperation.String(operation),
+	))
+}
+
+// endResourceSpan records the outcome of the operation, as given by its diagnostics, and ends the span. It is meant
+// to be deferred, so that the final diagnostics are recorded.
+func endResourceSpan(span trace.Span, diags *diag.Diagnostics) {
+	outcome := outcomeSuccess
+	if diags.HasError() {
+		outcome = outcomeError
+		span.SetStatus(codes.Error, redactSecrets(diags.Errors()[0].Summary()))
+	}
+	span.SetAttributes(attributeOutcome.String(outcome))
+	span.End()
+}
+
+// requestSpanKey holds the span of a MediaTailor request in the request context, so that the span of the resource
+// operation is never ended in its place.
+type requestSpanKey struct{}
+
+// startRequestSpanHandler starts a span for every MediaTailor request, as a child of the span of the resource
+// operation. It runs before the request is validated, so that the span also covers invalid requests.
+var startRequestSpanHandler = request.NamedHandler{Name: "awsmt.StartRequestSpan", Fn: func(r *request.Request) {
+	ctx, span := otel.Tracer(tracerName).Start(r.Context(), "MediaTailor."+r.Operation.Name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemKey.String("aws-api"),
+			semconv.RPCService("MediaTailor"),
+			semconv.RPCMethod(r.Operation.Name),
+		),
+	)
+	r.SetContext(context.WithValue(ctx, requestSpanKey{}, span))
+}}
+
+// endRequestSpanHandler records the outcome of a MediaTailor request, including its retries, and ends its span.
+var endRequestSpanHandler = request.NamedHandler{Name: "awsmt.EndRequestSpan", Fn: func(r *request.Request) {
+	span, ok := r.Context().Value(requestSpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(attributeRetryCount.Int(r.RetryCount), attributeRequestID.String(r.RequestID))
+	if r.HTTPResponse != nil && r.HTTPResponse.StatusCode != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(r.HTTPResponse.StatusCode))
+	}
+	outcome := outcomeSuccess
+	if r.Error != nil {
+		outcome = outcomeError
+		span.SetStatus(codes.Error, redactSecrets(r.Error.Error()))
+	}
+	span.SetAttributes(attributeOutcome.String(outcome))
+	span.End()
+}}
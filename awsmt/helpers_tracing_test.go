@@ -0,0 +1,132 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"regexp"
+	"testing"
+)
+
+// recordSpans installs a tracer provider that records the spans in memory until the test ends.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	previous := otel.GetTracerProvider()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, a := range span.Attributes() {
+		if a.Key == key {
+			return a.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestTracingChannelLifecycle(t *testing.T) {
+	spans := recordSpans(t)
+	server, _ := newEmulatorClient(t)
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: emulatorProvider(server.URL) + offlineChannel("RUNNING", 60, nil),
+			},
+			{
+				Config:      emulatorProvider(server.URL) + offlineChannelWithFillerSlate("STOPPED", 60, nil, `{ source_location_name = "test", vod_source_name = "slate" }`),
+				ExpectError: regexp.MustCompile("Error while updating channel"),
+			},
+		},
+	})
+
+	var created, failed sdktrace.ReadOnlySpan
+	for _, span := range spans.Ended() {
+		if span.Name() == "awsmt_channel.create" {
+			created = span
+		}
+		if span.Name() == "awsmt_channel.update" && spanAttribute(span, attributeOutcome) == outcomeError {
+			failed = span
+		}
+	}
+	if created == nil {
+		t.Fatal("expected a span for the creation of the channel")
+	}
+	if spanAttribute(created, attributeResourceName) != "test" || spanAttribute(created, attributeOutcome) != outcomeSuccess {
+		t.Errorf("unexpected attributes %v", created.Attributes())
+	}
+	var requests []string
+	for _, span := range spans.Ended() {
+		if span.Parent().SpanID() == created.SpanContext().SpanID() {
+			requests = append(requests, span.Name())
+			if spanAttribute(span, attributeRequestID) == "" {
+				t.Errorf("expected the request span %s to have a request ID", span.Name())
+			}
+		}
+	}
+	expected := []string{"MediaTailor.CreateChannel", "MediaTailor.StartChannel", "MediaTailor.PutChannelPolicy"}
+	if len(requests) != len(expected) {
+		t.Fatalf("expected the request spans %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("expected the request spans %v, got %v", expected, requests)
+		}
+	}
+
+	// A LOOP channel cannot have a filler slate, so the update of the second step fails.
+	if failed == nil || failed.Status().Code != codes.Error {
+		t.Error("expected a failed update span")
+	}
+}
+
+func TestStartTracing(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	ctx := context.Background()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	shutdown, err := StartTracing(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if otel.GetTracerProvider() != previous {
+		t.Error("expected tracing to stay disabled without an OTLP endpoint")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://127.0.0.1:4318")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/json")
+	if _, err := StartTracing(ctx); err == nil {
+		t.Error("expected an unsupported protocol to be rejected")
+	}
+
+	for _, protocol := range []string{"grpc", "http/protobuf"} {
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", protocol)
+		shutdown, err := StartTracing(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); !ok {
+			t.Errorf("expected a tracer provider to be installed for %s", protocol)
+		}
+		if err := shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -1,9 +1,10 @@
 package awsmt
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
+	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
 )
 
 func vodSourceInput(plan vodSourceModel) mediatailor.CreateVodSourceInput {
@@ -30,7 +31,7 @@ func readVodSourceToPlan(plan vodSourceModel, vodSource mediatailor.CreateVodSou
 	}
 
 	if vodSource.CreationTime != nil {
-		plan.CreationTime = types.StringValue((aws.TimeValue(vodSource.CreationTime)).String())
+		plan.CreationTime = timestampValue(vodSource.CreationTime)
 	}
 
 	if vodSource.HttpPackageConfigurations != nil && len(vodSource.HttpPackageConfigurations) > 0 {
@@ -45,7 +46,7 @@ func readVodSourceToPlan(plan vodSourceModel, vodSource mediatailor.CreateVodSou
 	}
 
 	if vodSource.LastModifiedTime != nil {
-		plan.LastModifiedTime = types.StringValue((aws.TimeValue(vodSource.LastModifiedTime)).String())
+		plan.LastModifiedTime = timestampValue(vodSource.LastModifiedTime)
 	}
 
 	if vodSource.VodSourceName != nil {
@@ -91,11 +92,11 @@ func readVodSourceToState(plan vodSourceModel, vodSource mediatailor.DescribeVod
 	}
 
 	if vodSource.LastModifiedTime != nil {
-		plan.LastModifiedTime = types.StringValue((aws.TimeValue(vodSource.LastModifiedTime)).String())
+		plan.LastModifiedTime = timestampValue(vodSource.LastModifiedTime)
 	}
 
 	if vodSource.CreationTime != nil {
-		plan.CreationTime = types.StringValue((aws.TimeValue(vodSource.CreationTime)).String())
+		plan.CreationTime = timestampValue(vodSource.CreationTime)
 	}
 
 	if vodSource.SourceLocationName != nil {
@@ -139,3 +140,49 @@ func getBasicVodSourceInput(plan *vodSourceModel) ([]*mediatailor.HttpPackageCon
 	}
 	return httpPackageConfigurations, vodSourceName, sourceLocationName
 }
+
+// getVodSourceNamesFromSelectors resolves the source location name and name of the VOD source a data source refers
+// to, using whichever of the name, arn or tags selectors is set.
+func getVodSourceNamesFromSelectors(ctx context.Context, client mediaTailorClient, data vodSourceDataSourceModel) (*string, *string, error) {
+	if !data.Arn.IsNull() {
+		names, err := resolveNamesFromArn(ctx, client, data.Arn.ValueString(), "vodSource")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &names[0], &names[1], nil
+	}
+	if data.Tags != nil {
+		return findVodSourceByTags(ctx, client, data.SourceLocationName, data.Tags)
+	}
+	return data.SourceLocationName, data.Name, nil
+}
+
+func findVodSourceByTags(ctx context.Context, client mediaTailorClient, sourceLocationName *string, tags map[string]*string) (*string, *string, error) {
+	if len(tags) == 0 {
+		return nil, nil, errEmptyTagFilter
+	}
+	sourceLocationNames, err := listSourceLocationNames(ctx, client, sourceLocationName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var matches []string
+	for _, name := range sourceLocationNames {
+		err := client.ListVodSourcesPagesWithContext(ctx, &mediatailor.ListVodSourcesInput{SourceLocationName: name}, func(page *mediatailor.ListVodSourcesOutput, _ bool) bool {
+			for _, vodSource := range page.Items {
+				if tagsMatch(vodSource.Tags, tags) {
+					matches = append(matches, *vodSource.SourceLocationName+","+*vodSource.VodSourceName)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	id, err := singleMatch("VOD source", matches)
+	if err != nil {
+		return nil, nil, err
+	}
+	idParts := strings.SplitN(id, ",", 2)
+	return &idParts[0], &idParts[1], nil
+}
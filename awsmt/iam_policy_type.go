@@ -0,0 +1,277 @@
+package awsmt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	_ basetypes.StringTypable                    = (*iamPolicyType)(nil)
+	_ xattr.TypeWithValidate                     = (*iamPolicyType)(nil)
+	_ basetypes.StringValuable                   = (*iamPolicy)(nil)
+	_ basetypes.StringValuableWithSemanticEquals = (*iamPolicy)(nil)
+)
+
+// @ADR
+// Context: MediaTailor returns channel policies with statements reordered and single values wrapped in arrays, so
+// jsontypes.Normalized, which only ignores whitespace, reported a change and updated the policy on every apply.
+// Decision: We decided to add a custom string type comparing policies by meaning, the same way IAM evaluates them.
+// Consequences: Reformatting a policy in the configuration no longer results in a plan, but the state keeps the
+// formatting of the last applied configuration rather than the one returned by MediaTailor.
+
+// iamPolicyType is a JSON string type whose values are equal if they describe the same IAM policy.
+type iamPolicyType struct {
+	basetypes.StringType
+}
+
+func (t iamPolicyType) String() string {
+	return "awsmt.iamPolicyType"
+}
+
+func (t iamPolicyType) ValueType(_ context.Context) attr.Value {
+	return iamPolicy{}
+}
+
+func (t iamPolicyType) Equal(o attr.Type) bool {
+	other, ok := o.(iamPolicyType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t iamPolicyType) Validate(_ context.Context, in tftypes.Value, path path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if in.Type() == nil || !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var value string
+	if err := in.As(&value); err != nil {
+		diags.AddAttributeError(path, "IAM Policy Type Validation Error", err.Error())
+		return diags
+	}
+	if _, err := canonicalIamPolicy(value); err != nil {
+		diags.AddAttributeError(path, "Invalid IAM Policy", "The value is not a valid IAM policy document: "+err.Error())
+	}
+	return diags
+}
+
+func (t iamPolicyType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return iamPolicy{StringValue: in}, nil
+}
+
+func (t iamPolicyType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return iamPolicy{StringValue: stringValue}, nil
+}
+
+// iamPolicy is a value of iamPolicyType.
+type iamPolicy struct {
+	basetypes.StringValue
+}
+
+func newIamPolicyNull() iamPolicy {
+	return iamPolicy{StringValue: basetypes.NewStringNull()}
+}
+
+func newIamPolicyPointerValue(value *string) iamPolicy {
+	return iamPolicy{StringValue: basetypes.NewStringPointerValue(value)}
+}
+
+func (v iamPolicy) Type(_ context.Context) attr.Type {
+	return iamPolicyType{}
+}
+
+func (v iamPolicy) Equal(o attr.Value) bool {
+	other, ok := o.(iamPolicy)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v iamPolicy) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(iamPolicy)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, newValuable))
+		return false, diags
+	}
+
+	equal, err := iamPoliciesEqual(v.ValueString(), newValue.ValueString())
+	if err != nil {
+		diags.AddError("Semantic Equality Check Error", err.Error())
+	}
+	return equal, diags
+}
+
+// iamPoliciesEqual reports whether two policy documents grant the same permissions.
+func iamPoliciesEqual(a, b string) (bool, error) {
+	canonicalA, err := canonicalIamPolicy(a)
+	if err != nil {
+		return false, err
+	}
+	canonicalB, err := canonicalIamPolicy(b)
+	if err != nil {
+		return false, err
+	}
+	return canonicalA == canonicalB, nil
+}
+
+// awsAccountRoot matches the root ARN of an account in any partition, such as aws, aws-cn or aws-us-gov.
+var awsAccountRoot = regexp.MustCompile(`^arn:aws[a-z-]*:iam::(\d{12}):root$`)
+
+// canonicalIamPolicy returns the policy as JSON with a single representation for every equivalent formatting:
+// statements are sorted, single values are wrapped in sorted lists, "*" principals are written as {"AWS": ["*"]},
+// root ARNs as account IDs, actions in lower case, and empty Sids are dropped.
+func canonicalIamPolicy(policy string) (string, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return "", err
+	}
+
+	var statements []interface{}
+	switch s := document["Statement"].(type) {
+	case []interface{}:
+		statements = s
+	case map[string]interface{}:
+		statements = []interface{}{s}
+	case nil:
+	default:
+		return "", fmt.Errorf("Statement must be an object or a list of objects")
+	}
+
+	canonicalStatements := make([]string, len(statements))
+	for i, s := range statements {
+		statement, ok := s.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("Statement must be an object or a list of objects")
+		}
+		canonical, err := json.Marshal(canonicalIamStatement(statement))
+		if err != nil {
+			return "", err
+		}
+		canonicalStatements[i] = string(canonical)
+	}
+	sort.Strings(canonicalStatements)
+
+	document["Statement"] = canonicalStatements
+	canonical, err := json.Marshal(document)
+	return string(canonical), err
+}
+
+func canonicalIamStatement(statement map[string]interface{}) map[string]interface{} {
+	canonical := map[string]interface{}{}
+	for key, value := range statement {
+		switch key {
+		case "Sid":
+			if value != "" {
+				canonical[key] = value
+			}
+		case "Action", "NotAction":
+			// IAM action names are case-insensitive.
+			actions := sortedStrings(value)
+			for i, action := range actions {
+				actions[i] = strings.ToLower(action)
+			}
+			sort.Strings(actions)
+			canonical[key] = actions
+		case "Resource", "NotResource":
+			canonical[key] = sortedStrings(value)
+		case "Principal", "NotPrincipal":
+			canonical[key] = canonicalIamPrincipal(value)
+		case "Condition":
+			canonical[key] = canonicalIamCondition(value)
+		default:
+			canonical[key] = value
+		}
+	}
+	return canonical
+}
+
+func canonicalIamPrincipal(principal interface{}) interface{} {
+	if principal == "*" {
+		return map[string]interface{}{"AWS": []string{"*"}}
+	}
+	principals, ok := principal.(map[string]interface{})
+	if !ok {
+		return principal
+	}
+	canonical := map[string]interface{}{}
+	for principalType, identifiers := range principals {
+		values := sortedStrings(identifiers)
+		if principalType == "AWS" {
+			// An account ID is returned as the root ARN of the account, whose partition depends on the region.
+			for i, value := range values {
+				if match := awsAccountRoot.FindStringSubmatch(value); match != nil {
+					values[i] = match[1]
+				}
+			}
+			sort.Strings(values)
+		}
+		canonical[principalType] = values
+	}
+	return canonical
+}
+
+func canonicalIamCondition(condition interface{}) interface{} {
+	operators, ok := condition.(map[string]interface{})
+	if !ok {
+		return condition
+	}
+	canonical := map[string]interface{}{}
+	for operator, keys := range operators {
+		values, ok := keys.(map[string]interface{})
+		if !ok {
+			canonical[operator] = keys
+			continue
+		}
+		canonicalValues := map[string]interface{}{}
+		for key, value := range values {
+			canonicalValues[key] = sortedStrings(value)
+		}
+		canonical[operator] = canonicalValues
+	}
+	return canonical
+}
+
+// sortedStrings turns a single value or a list of values into a sorted list of their JSON representations.
+func sortedStrings(value interface{}) []string {
+	var values []interface{}
+	if list, ok := value.([]interface{}); ok {
+		values = list
+	} else {
+		values = []interface{}{value}
+	}
+
+	result := make([]string, len(values))
+	for i, v := range values {
+		if s, ok := v.(string); ok {
+			result[i] = s
+		} else {
+			encoded, _ := json.Marshal(v)
+			result[i] = string(encoded)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
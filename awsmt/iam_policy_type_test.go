@@ -0,0 +1,68 @@
+package awsmt
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIamPoliciesEqual(t *testing.T) {
+	configured := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "", "Effect": "Allow", "Principal": "*", "Action": "mediatailor:GetManifest", "Resource": "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"},
+			{"Effect": "Allow", "Principal": {"AWS": "123456789012"}, "Action": ["mediatailor:GetManifest", "mediatailor:DescribeChannel"], "Resource": "*",
+			 "Condition": {"StringEquals": {"aws:SourceVpce": "vpce-1"}}}
+		]
+	}`
+	returned := `{"Version":"2012-10-17","Statement":[` +
+		`{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root"]},"Action":["mediatailor:DescribeChannel","mediatailor:GetManifest"],"Resource":["*"],"Condition":{"StringEquals":{"aws:SourceVpce":["vpce-1"]}}},` +
+		`{"Effect":"Allow","Principal":{"AWS":"*"},"Action":["mediatailor:GetManifest"],"Resource":["arn:aws:mediatailor:eu-central-1:123456789012:channel/test"]}]}`
+
+	equal, err := iamPoliciesEqual(configured, returned)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !equal {
+		t.Error("expected the policies to be equal")
+	}
+}
+
+func TestIamPoliciesEqualInOtherPartitions(t *testing.T) {
+	configured := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["123456789012","111122223333"]},"Action":"MediaTailor:GetManifest","Resource":"*"}]}`
+	for _, partition := range []string{"aws", "aws-cn", "aws-us-gov"} {
+		returned := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:` + partition + `:iam::111122223333:root","arn:` + partition + `:iam::123456789012:root"]},"Action":"mediatailor:GetManifest","Resource":"*"}]}`
+		equal, err := iamPoliciesEqual(configured, returned)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !equal {
+			t.Errorf("expected the policies to be equal in the %s partition", partition)
+		}
+	}
+}
+
+func TestIamPoliciesNotEqual(t *testing.T) {
+	a := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"mediatailor:GetManifest","Resource":"*"}]}`
+	b := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"mediatailor:GetManifest","Resource":"*"}]}`
+
+	equal, err := iamPoliciesEqual(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if equal {
+		t.Error("expected the policies to differ")
+	}
+}
+
+func TestIamPolicySemanticEquals(t *testing.T) {
+	a := `{"Statement":{"Effect":"Allow","Action":"mediatailor:*","Resource":"*"}}`
+	b := `{"Statement":[{"Effect":"Allow","Action":["mediatailor:*"],"Resource":["*"]}]}`
+
+	equal, diags := newIamPolicyPointerValue(&a).StringSemanticEquals(context.Background(), newIamPolicyPointerValue(&b))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !equal {
+		t.Error("expected the policies to be equal")
+	}
+}
@@ -1,16 +1,63 @@
 package awsmt
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	datasourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type liveSourceModel struct {
 	ID                        types.String                     `tfsdk:"id"`
 	Arn                       types.String                     `tfsdk:"arn"`
-	CreationTime              types.String                     `tfsdk:"creation_time"`
+	CreationTime              timestamp                        `tfsdk:"creation_time"`
 	HttpPackageConfigurations []httpPackageConfigurationsModel `tfsdk:"http_package_configurations"`
-	LastModifiedTime          types.String                     `tfsdk:"last_modified_time"`
+	LastModifiedTime          timestamp                        `tfsdk:"last_modified_time"`
 	Name                      *string                          `tfsdk:"name"`
 	SourceLocationName        *string                          `tfsdk:"source_location_name"`
 	Tags                      map[string]*string               `tfsdk:"tags"`
+	Timeouts                  timeouts.Value                   `tfsdk:"timeouts"`
+}
+
+// liveSourceDataSourceModel holds the attributes of liveSourceModel, with the timeouts of a data source.
+type liveSourceDataSourceModel struct {
+	ID                        types.String                     `tfsdk:"id"`
+	Arn                       types.String                     `tfsdk:"arn"`
+	CreationTime              timestamp                        `tfsdk:"creation_time"`
+	HttpPackageConfigurations []httpPackageConfigurationsModel `tfsdk:"http_package_configurations"`
+	LastModifiedTime          timestamp                        `tfsdk:"last_modified_time"`
+	Name                      *string                          `tfsdk:"name"`
+	SourceLocationName        *string                          `tfsdk:"source_location_name"`
+	Tags                      map[string]*string               `tfsdk:"tags"`
+	Timeouts                  datasourcetimeouts.Value         `tfsdk:"timeouts"`
+}
+
+// liveSourceModel returns the data source model without its timeouts, so that it can be read with the helpers of the
+// resource.
+func (m liveSourceDataSourceModel) liveSourceModel() liveSourceModel {
+	return liveSourceModel{
+		ID:                        m.ID,
+		Arn:                       m.Arn,
+		CreationTime:              m.CreationTime,
+		HttpPackageConfigurations: m.HttpPackageConfigurations,
+		LastModifiedTime:          m.LastModifiedTime,
+		Name:                      m.Name,
+		SourceLocationName:        m.SourceLocationName,
+		Tags:                      m.Tags,
+	}
+}
+
+func newLiveSourceDataSourceModel(m liveSourceModel, dataSourceTimeouts datasourcetimeouts.Value) liveSourceDataSourceModel {
+	return liveSourceDataSourceModel{
+		ID:                        m.ID,
+		Arn:                       m.Arn,
+		CreationTime:              m.CreationTime,
+		HttpPackageConfigurations: m.HttpPackageConfigurations,
+		LastModifiedTime:          m.LastModifiedTime,
+		Name:                      m.Name,
+		SourceLocationName:        m.SourceLocationName,
+		Tags:                      m.Tags,
+		Timeouts:                  dataSourceTimeouts,
+	}
 }
 
 type httpPackageConfigurationsModel struct {
@@ -0,0 +1,299 @@
+package awsmt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// channelStatePrivateKey is the private state key under which the channel resource stores the channel_state
+// configured during the last apply.
+const channelStatePrivateKey = "channel_state"
+
+// lastAppliedChannelState encodes a configured channel_state for the private state. A null value removes the key.
+func lastAppliedChannelState(value types.String) []byte {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	encoded, _ := json.Marshal(value.ValueString())
+	return encoded
+}
+
+// ignoreChannelStateDrift keeps the refreshed channel_state in the plan when enforce_channel_state is false and the
+// configured channel_state has not changed since the last apply. A channel started or stopped outside of Terraform
+// is then reported by the refresh, but not reconciled.
+func ignoreChannelStateDrift() planmodifier.String {
+	return ignoreChannelStateDriftModifier{}
+}
+
+type ignoreChannelStateDriftModifier struct{}
+
+func (m ignoreChannelStateDriftModifier) Description(_ context.Context) string {
+	return "Ignores changes of the channel state made outside of Terraform when enforce_channel_state is false."
+}
+
+func (m ignoreChannelStateDriftModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m ignoreChannelStateDriftModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || req.ConfigValue.IsNull() || req.StateValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var enforce types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("enforce_channel_state"), &enforce)...)
+	if resp.Diagnostics.HasError() || enforce.IsUnknown() || enforce.IsNull() || enforce.ValueBool() {
+		return
+	}
+
+	lastApplied, diags := req.Private.GetKey(ctx, channelStatePrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A configuration that differs from the last applied one is a deliberate change, not drift.
+	if lastApplied != nil && string(lastApplied) != string(lastAppliedChannelState(req.ConfigValue)) {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+// useStateForUnknownUnlessChanged keeps the prior value of a computed attribute in the plan, unless one of the given
+// attributes changes. Unlike UseStateForUnknown, it still reports values that the changed inputs are known to
+// recompute, such as an ARN when the name of the resource changes. Relative expressions are resolved against the
+// modified attribute.
+func useStateForUnknownUnlessChanged(expressions ...path.Expression) useStateForUnknownUnlessChangedModifier {
+	return useStateForUnknownUnlessChangedModifier{expressions: expressions}
+}
+
+type useStateForUnknownUnlessChangedModifier struct {
+	expressions path.Expressions
+}
+
+func (m useStateForUnknownUnlessChangedModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Once set, the value of this attribute in state will not change unless %s changes.", m.expressions)
+}
+
+func (m useStateForUnknownUnlessChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.PlanValue.IsUnknown() || !req.ConfigValue.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	if m.inputsUnchanged(ctx, req.PathExpression, req.Plan, req.State, &resp.Diagnostics) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if !req.PlanValue.IsUnknown() || !req.ConfigValue.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	if m.inputsUnchanged(ctx, req.PathExpression, req.Plan, req.State, &resp.Diagnostics) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyObject(ctx context.Context, req planmodifier.ObjectRequest, resp *planmodifier.ObjectResponse) {
+	if !req.PlanValue.IsUnknown() || !req.ConfigValue.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	if m.inputsUnchanged(ctx, req.PathExpression, req.Plan, req.State, &resp.Diagnostics) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// inputsUnchanged reports whether every attribute matched by the expressions has a known planned value equal to its
+// value in state. An attribute missing from the state, such as a new list element, counts as changed.
+func (m useStateForUnknownUnlessChangedModifier) inputsUnchanged(ctx context.Context, current path.Expression, plan tfsdk.Plan, state tfsdk.State, diags *diag.Diagnostics) bool {
+	for _, expression := range current.MergeExpressions(m.expressions...) {
+		paths, d := plan.PathMatches(ctx, expression)
+		diags.Append(d...)
+		if diags.HasError() {
+			return false
+		}
+
+		for _, p := range paths {
+			var planned, prior attr.Value
+			diags.Append(plan.GetAttribute(ctx, p, &planned)...)
+			if diags.HasError() {
+				return false
+			}
+			if d := state.GetAttribute(ctx, p, &prior); d.HasError() {
+				return false
+			}
+			if planned == nil || planned.IsUnknown() || !planned.Equal(prior) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// useStateForUnknownInSet keeps the prior value of a computed attribute of a set element in the plan. Set elements
+// have no stable position, so the prior value is taken from the element in state whose key attribute equals the one
+// of the planned element. Like useStateForUnknownUnlessChanged, the value stays unknown when one of the given
+// attributes changes.
+func useStateForUnknownInSet(key string, expressions ...path.Expression) planmodifier.String {
+	return useStateForUnknownInSetModifier{
+		useStateForUnknownUnlessChangedModifier: useStateForUnknownUnlessChanged(expressions...),
+		key:                                     key,
+	}
+}
+
+type useStateForUnknownInSetModifier struct {
+	useStateForUnknownUnlessChangedModifier
+	key string
+}
+
+func (m useStateForUnknownInSetModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("%s Set elements are matched by %s.", m.useStateForUnknownUnlessChangedModifier.Description(ctx), m.key)
+}
+
+func (m useStateForUnknownInSetModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownInSetModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.PlanValue.IsUnknown() || !req.ConfigValue.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	if !m.inputsUnchanged(ctx, req.PathExpression, req.Plan, req.State, &resp.Diagnostics) {
+		return
+	}
+
+	step, _ := req.Path.Steps().LastStep()
+	attribute, ok := step.(path.PathStepAttributeName)
+	if !ok {
+		return
+	}
+	element := req.Path.ParentPath()
+
+	var key attr.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, element.AtName(m.key), &key)...)
+	if resp.Diagnostics.HasError() || key == nil || key.IsUnknown() || key.IsNull() {
+		return
+	}
+
+	var prior types.Set
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, element.ParentPath(), &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, priorElement := range prior.Elements() {
+		object, ok := priorElement.(types.Object)
+		if !ok || !key.Equal(object.Attributes()[m.key]) {
+			continue
+		}
+		if value, ok := object.Attributes()[string(attribute)].(types.String); ok {
+			resp.PlanValue = value
+		}
+		return
+	}
+}
+
+// defaultInSet plans the given value for an attribute nested in a set element when the attribute is not configured,
+// like a schema default. Schema defaults cannot be used in sets: the framework looks up the configuration of a set
+// element by its position, which differs from the one of the planned element once elements carry computed values
+// from the prior state, and would overwrite configured values with the default. The configuration is therefore taken
+// from the set element whose key attribute equals the one of the planned element. Without a default, Terraform would
+// keep the prior value of an attribute that is removed from the configuration.
+func defaultInSet(key string, value attr.Value) defaultInSetModifier {
+	return defaultInSetModifier{key: key, value: value}
+}
+
+type defaultInSetModifier struct {
+	key   string
+	value attr.Value
+}
+
+func (m defaultInSetModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Defaults to %s when not configured. Set elements are matched by %s.", m.value, m.key)
+}
+
+func (m defaultInSetModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m defaultInSetModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if m.usesDefault(ctx, req.Path, req.Plan, req.Config, req.PlanValue, &resp.Diagnostics) {
+		resp.PlanValue = m.value.(types.Int64)
+	}
+}
+
+func (m defaultInSetModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if m.usesDefault(ctx, req.Path, req.Plan, req.Config, req.PlanValue, &resp.Diagnostics) {
+		resp.PlanValue = m.value.(types.List)
+	}
+}
+
+// usesDefault reports whether the attribute at p is not configured. If the configured set element cannot be found,
+// it falls back to whether the planned value is unknown.
+func (m defaultInSetModifier) usesDefault(ctx context.Context, p path.Path, plan tfsdk.Plan, config tfsdk.Config, planned attr.Value, diags *diag.Diagnostics) bool {
+	configured := m.configValue(ctx, p, plan, config, diags)
+	if diags.HasError() {
+		return false
+	}
+	if configured == nil {
+		return planned.IsUnknown()
+	}
+	return configured.IsNull()
+}
+
+// configValue returns the configured value of the attribute at p, or nil if the set element it is nested in has no
+// known key or is not configured.
+func (m defaultInSetModifier) configValue(ctx context.Context, p path.Path, plan tfsdk.Plan, config tfsdk.Config, diags *diag.Diagnostics) attr.Value {
+	element := p
+	var names []string
+	for {
+		step, _ := element.Steps().LastStep()
+		if _, ok := step.(path.PathStepElementKeyValue); ok {
+			break
+		}
+		name, ok := step.(path.PathStepAttributeName)
+		if !ok {
+			return nil
+		}
+		names = append([]string{string(name)}, names...)
+		element = element.ParentPath()
+	}
+
+	var key attr.Value
+	diags.Append(plan.GetAttribute(ctx, element.AtName(m.key), &key)...)
+	if diags.HasError() || key == nil || key.IsUnknown() || key.IsNull() {
+		return nil
+	}
+
+	var configured types.Set
+	diags.Append(config.GetAttribute(ctx, element.ParentPath(), &configured)...)
+	if diags.HasError() {
+		return nil
+	}
+	for _, configuredElement := range configured.Elements() {
+		object, ok := configuredElement.(types.Object)
+		if !ok || !key.Equal(object.Attributes()[m.key]) {
+			continue
+		}
+		var value attr.Value = object
+		for _, name := range names {
+			parent, ok := value.(types.Object)
+			if !ok || parent.IsNull() || parent.IsUnknown() {
+				return nil
+			}
+			value = parent.Attributes()[name]
+		}
+		return value
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+package awsmt
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"os"
+	"testing"
+)
+
+// modifyPlaybackUrls runs the plan modifier of the playback_url of every channel output. The prior state is the
+// recorded channel state with a second output, and the plan is derived from it by the given change, with the
+// playback URLs unknown as they are before the modifiers run. It returns the planned playback URLs by manifest name.
+func modifyPlaybackUrls(t *testing.T, change func(state map[string]interface{})) map[string]types.String {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	ResourceChannel().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	terraformType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	raw, err := os.ReadFile("testdata/state/channel_v2.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recorded map[string]interface{}
+	if err := json.Unmarshal(raw, &recorded); err != nil {
+		t.Fatal(err)
+	}
+	recorded["outputs"] = append(recorded["outputs"].([]interface{}), map[string]interface{}{
+		"dash_playlist_settings": nil,
+		"hls_playlist_settings":  nil,
+		"manifest_name":          "second",
+		"playback_url":           "https://channel-assembly.mediatailor.eu-central-1.amazonaws.com/v1/channel/test/second.m3u8",
+		"source_group":           "default",
+	})
+	decode := func(state map[string]interface{}) tftypes.Value {
+		encoded, err := json.Marshal(state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := tftypes.ValueFromJSON(encoded, terraformType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: decode(recorded)}
+
+	change(recorded)
+	planned, err := tftypes.Transform(decode(recorded), func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if p.LastStep() == tftypes.AttributeName("playback_url") {
+			return tftypes.NewValue(tftypes.String, tftypes.UnknownValue), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned}
+
+	var outputs types.Set
+	if diags := plan.GetAttribute(ctx, path.Root("outputs"), &outputs); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	urls := map[string]types.String{}
+	for _, element := range outputs.Elements() {
+		playbackUrl := path.Root("outputs").AtSetValue(element).AtName("playback_url")
+		req := planmodifier.StringRequest{
+			Path:           playbackUrl,
+			PathExpression: playbackUrl.Expression(),
+			Config:         tfsdk.Config{Schema: schemaResp.Schema, Raw: planned},
+			ConfigValue:    types.StringNull(),
+			Plan:           plan,
+			PlanValue:      types.StringUnknown(),
+			State:          state,
+		}
+		resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+		useStateForUnknownInSet("manifest_name", path.MatchRoot("name")).PlanModifyString(ctx, req, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		urls[element.(types.Object).Attributes()["manifest_name"].(types.String).ValueString()] = resp.PlanValue
+	}
+	return urls
+}
+
+func TestUseStateForUnknownInSet(t *testing.T) {
+	urls := modifyPlaybackUrls(t, func(state map[string]interface{}) {
+		state["tier"] = "STANDARD"
+	})
+	if urls["default"].ValueString() != "https://channel-assembly.mediatailor.eu-central-1.amazonaws.com/v1/channel/test/default.m3u8" {
+		t.Errorf("expected the playback URL of the default output to be kept, got %s", urls["default"])
+	}
+	if urls["second"].ValueString() != "https://channel-assembly.mediatailor.eu-central-1.amazonaws.com/v1/channel/test/second.m3u8" {
+		t.Errorf("expected the playback URL of the second output to be kept, got %s", urls["second"])
+	}
+
+	urls = modifyPlaybackUrls(t, func(state map[string]interface{}) {
+		state["outputs"].([]interface{})[1].(map[string]interface{})["manifest_name"] = "renamed"
+	})
+	if urls["default"].IsUnknown() {
+		t.Error("expected the playback URL of the unchanged output to be kept")
+	}
+	if !urls["renamed"].IsUnknown() {
+		t.Errorf("expected the playback URL of the renamed output to be unknown, got %s", urls["renamed"])
+	}
+
+	urls = modifyPlaybackUrls(t, func(state map[string]interface{}) {
+		state["name"] = "other"
+	})
+	for manifestName, url := range urls {
+		if !url.IsUnknown() {
+			t.Errorf("expected the playback URL of %s to be unknown after a change of the channel name, got %s", manifestName, url)
+		}
+	}
+}
+
+// modifyManifestWindows runs the plan modifier of hls_playlist_settings.manifest_window_seconds of every channel
+// output. The prior state is the recorded channel state with a second output, whose manifest window is 120 seconds.
+// Like Terraform, the proposed plan keeps the prior values of attributes that are not configured, and the
+// configuration is derived from the prior state by the given change, with the outputs in reverse order. It returns
+// the planned manifest windows by manifest name.
+func modifyManifestWindows(t *testing.T, change func(outputs []interface{})) map[string]types.Int64 {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	ResourceChannel().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	terraformType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	read := func() map[string]interface{} {
+		raw, err := os.ReadFile("testdata/state/channel_v2.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var recorded map[string]interface{}
+		if err := json.Unmarshal(raw, &recorded); err != nil {
+			t.Fatal(err)
+		}
+		recorded["outputs"] = append(recorded["outputs"].([]interface{}), map[string]interface{}{
+			"dash_playlist_settings": nil,
+			"hls_playlist_settings":  map[string]interface{}{"ad_markup_type": []string{"DATERANGE"}, "manifest_window_seconds": 120},
+			"manifest_name":          "second",
+			"playback_url":           "https://channel-assembly.mediatailor.eu-central-1.amazonaws.com/v1/channel/test/second.m3u8",
+			"source_group":           "default",
+		})
+		return recorded
+	}
+	decode := func(state map[string]interface{}) tftypes.Value {
+		encoded, err := json.Marshal(state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := tftypes.ValueFromJSON(encoded, terraformType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+	prior := decode(read())
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: prior}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: prior}
+
+	configured := read()
+	outputs := configured["outputs"].([]interface{})
+	outputs[0], outputs[1] = outputs[1], outputs[0]
+	change(outputs)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: decode(configured)}
+
+	var planned types.Set
+	if diags := plan.GetAttribute(ctx, path.Root("outputs"), &planned); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	windows := map[string]types.Int64{}
+	for _, element := range planned.Elements() {
+		manifestWindow := path.Root("outputs").AtSetValue(element).AtName("hls_playlist_settings").AtName("manifest_window_seconds")
+		planValue := element.(types.Object).Attributes()["hls_playlist_settings"].(types.Object).Attributes()["manifest_window_seconds"].(types.Int64)
+		req := planmodifier.Int64Request{
+			Path:           manifestWindow,
+			PathExpression: manifestWindow.Expression(),
+			Config:         config,
+			Plan:           plan,
+			PlanValue:      planValue,
+			State:          state,
+		}
+		resp := planmodifier.Int64Response{PlanValue: req.PlanValue}
+		defaultInSet("manifest_name", types.Int64Value(defaultManifestWindowSeconds)).PlanModifyInt64(ctx, req, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		windows[element.(types.Object).Attributes()["manifest_name"].(types.String).ValueString()] = resp.PlanValue
+	}
+	return windows
+}
+
+func TestDefaultInSet(t *testing.T) {
+	windows := modifyManifestWindows(t, func(outputs []interface{}) {})
+	if windows["default"].ValueInt64() != 30 || windows["second"].ValueInt64() != 120 {
+		t.Errorf("expected the configured manifest windows to be kept, got %v", windows)
+	}
+
+	windows = modifyManifestWindows(t, func(outputs []interface{}) {
+		outputs[0].(map[string]interface{})["hls_playlist_settings"].(map[string]interface{})["manifest_window_seconds"] = nil
+	})
+	if windows["second"].ValueInt64() != defaultManifestWindowSeconds {
+		t.Errorf("expected the default manifest window to be planned after removing it from the configuration, got %s", windows["second"])
+	}
+	if windows["default"].ValueInt64() != 30 {
+		t.Errorf("expected the configured manifest window of the other output to be kept, got %s", windows["default"])
+	}
+}
+
+// planChannelState plans the recorded channel state with the given enforce_channel_state, configured channel_state
+// and remote channel_state through the provider server, with the given channel_state applied last in the private
+// state, or none if it is empty. It returns the planned channel_state.
+func planChannelState(t *testing.T, enforce bool, lastApplied, configured, remote string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	ResourceChannel().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	terraformType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	raw, err := os.ReadFile("testdata/state/channel_v2.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dynamicValue := func(change func(state map[string]interface{})) *tfprotov6.DynamicValue {
+		var state map[string]interface{}
+		if err := json.Unmarshal(raw, &state); err != nil {
+			t.Fatal(err)
+		}
+		state["enforce_channel_state"] = enforce
+		change(state)
+		encoded, err := json.Marshal(state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := tftypes.ValueFromJSON(encoded, terraformType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dynamic, err := tfprotov6.NewDynamicValue(terraformType, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &dynamic
+	}
+
+	var priorPrivate []byte
+	if lastApplied != "" {
+		priorPrivate, err = json.Marshal(map[string][]byte{channelStatePrivateKey: lastAppliedChannelState(types.StringValue(lastApplied))})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server, err := providerserver.NewProtocol6WithError(newWithClient(newFakeMediaTailor("eu-central-1", "123456789012")))()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:   "awsmt_channel",
+		PriorState: dynamicValue(func(state map[string]interface{}) { state["channel_state"] = remote }),
+		ProposedNewState: dynamicValue(func(state map[string]interface{}) {
+			state["channel_state"] = configured
+		}),
+		Config: dynamicValue(func(state map[string]interface{}) {
+			state["channel_state"] = configured
+			for _, computed := range []string{"arn", "creation_time", "id", "last_modified_time"} {
+				state[computed] = nil
+			}
+		}),
+		PriorPrivate: priorPrivate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	planned, err := resp.PlannedState.Unmarshal(terraformType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var channelState types.String
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned}
+	if diags := plan.GetAttribute(ctx, path.Root("channel_state"), &channelState); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	return channelState.ValueString()
+}
+
+func TestIgnoreChannelStateDrift(t *testing.T) {
+	for _, c := range []struct {
+		description string
+		enforce     bool
+		lastApplied string
+		configured  string
+		remote      string
+		expected    string
+	}{
+		{"a channel stopped outside of Terraform with enforce_channel_state", true, "RUNNING", "RUNNING", "STOPPED", "RUNNING"},
+		{"a channel stopped outside of Terraform", false, "RUNNING", "RUNNING", "STOPPED", "STOPPED"},
+		{"a channel started outside of Terraform", false, "STOPPED", "STOPPED", "RUNNING", "RUNNING"},
+		{"a channel changed outside of Terraform before the channel state was recorded", false, "", "RUNNING", "STOPPED", "STOPPED"},
+		{"a channel started in the configuration", false, "STOPPED", "RUNNING", "STOPPED", "RUNNING"},
+		{"a channel stopped in the configuration", false, "RUNNING", "STOPPED", "RUNNING", "STOPPED"},
+		{"a channel stopped in the configuration with enforce_channel_state", true, "RUNNING", "STOPPED", "RUNNING", "STOPPED"},
+		{"a channel without drift", false, "RUNNING", "RUNNING", "RUNNING", "RUNNING"},
+	} {
+		if got := planChannelState(t, c.enforce, c.lastApplied, c.configured, c.remote); got != c.expected {
+			t.Errorf("expected the plan of %s to be %s, got %s", c.description, c.expected, got)
+		}
+	}
+}
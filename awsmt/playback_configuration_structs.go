@@ -1,6 +1,11 @@
 package awsmt
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	datasourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type playbackConfigurationModel struct {
 	ID                   types.String                  `tfsdk:"id"`
@@ -11,10 +16,17 @@ type playbackConfigurationModel struct {
 	ConfigurationAliases map[string]map[string]*string `tfsdk:"configuration_aliases"`
 	DashConfiguration    *dashConfigurationModel       `tfsdk:"dash_configuration"`
 	// @ADR
-	// Context: The Provider Framework does not allow computed blocks
-	// Decision: We decided to flatten the Log Configuration and the HLS Configuration blocks into the resource.
-	// Consequences: The schema of the object differs from that of the SDK.
+	// Context: The Provider Framework did not allow computed blocks, so the Log Configuration and the HLS
+	// Configuration were flattened into the resource. Version 1.8 of the framework supports computed nested
+	// attributes.
+	// Decision: We decided to restore the hls_configuration and log_configuration objects, and to keep the flattened
+	// attributes as deprecated aliases for one major version. Version 1 of the schema adds the objects, and the
+	// state upgrader fills them from the flattened attributes.
+	// Consequences: Both shapes are read until the flattened attributes are removed. The objects are types.Object,
+	// because they are unknown while planning the creation of a playback configuration.
+	HlsConfiguration                       types.Object                   `tfsdk:"hls_configuration"`
 	HlsConfigurationManifestEndpointPrefix types.String                   `tfsdk:"hls_configuration_manifest_endpoint_prefix"`
+	LogConfiguration                       types.Object                   `tfsdk:"log_configuration"`
 	LogConfigurationPercentEnabled         types.Int64                    `tfsdk:"log_configuration_percent_enabled"`
 	LivePreRollConfiguration               *livePreRollConfigurationModel `tfsdk:"live_pre_roll_configuration"`
 	ManifestProcessingRules                *manifestProcessingRulesModel  `tfsdk:"manifest_processing_rules"`
@@ -27,6 +39,99 @@ type playbackConfigurationModel struct {
 	Tags                                   map[string]*string             `tfsdk:"tags"`
 	TranscodeProfileName                   *string                        `tfsdk:"transcode_profile_name"`
 	VideoContentSourceUrl                  *string                        `tfsdk:"video_content_source_url"`
+	Timeouts                               timeouts.Value                 `tfsdk:"timeouts"`
+}
+
+// playbackConfigurationDataSourceModel holds the attributes of playbackConfigurationModel, with the timeouts of a data source.
+type playbackConfigurationDataSourceModel struct {
+	ID                                     types.String                   `tfsdk:"id"`
+	AdDecisionServerUrl                    *string                        `tfsdk:"ad_decision_server_url"`
+	AvailSupression                        *availSupressionModel          `tfsdk:"avail_supression"`
+	Bumper                                 *bumperModel                   `tfsdk:"bumper"`
+	CdnConfiguration                       *cdnConfigurationModel         `tfsdk:"cdn_configuration"`
+	ConfigurationAliases                   map[string]map[string]*string  `tfsdk:"configuration_aliases"`
+	DashConfiguration                      *dashConfigurationModel        `tfsdk:"dash_configuration"`
+	HlsConfiguration                       types.Object                   `tfsdk:"hls_configuration"`
+	HlsConfigurationManifestEndpointPrefix types.String                   `tfsdk:"hls_configuration_manifest_endpoint_prefix"`
+	LogConfiguration                       types.Object                   `tfsdk:"log_configuration"`
+	LogConfigurationPercentEnabled         types.Int64                    `tfsdk:"log_configuration_percent_enabled"`
+	LivePreRollConfiguration               *livePreRollConfigurationModel `tfsdk:"live_pre_roll_configuration"`
+	ManifestProcessingRules                *manifestProcessingRulesModel  `tfsdk:"manifest_processing_rules"`
+	Name                                   *string                        `tfsdk:"name"`
+	PersonalizationThresholdSeconds        *int64                         `tfsdk:"personalization_threshold_seconds"`
+	PlaybackConfigurationArn               types.String                   `tfsdk:"playback_configuration_arn"`
+	PlaybackEndpointPrefix                 types.String                   `tfsdk:"playback_endpoint_prefix"`
+	SessionInitializationEndpointPrefix    types.String                   `tfsdk:"session_initialization_endpoint_prefix"`
+	SlateAdUrl                             *string                        `tfsdk:"slate_ad_url"`
+	Tags                                   map[string]*string             `tfsdk:"tags"`
+	TranscodeProfileName                   *string                        `tfsdk:"transcode_profile_name"`
+	VideoContentSourceUrl                  *string                        `tfsdk:"video_content_source_url"`
+	Timeouts                               datasourcetimeouts.Value       `tfsdk:"timeouts"`
+}
+
+// playbackConfigurationModel returns the data source model without its timeouts, so that it can be read with the helpers of the
+// resource.
+func (m playbackConfigurationDataSourceModel) playbackConfigurationModel() playbackConfigurationModel {
+	return playbackConfigurationModel{
+		ID:                                     m.ID,
+		AdDecisionServerUrl:                    m.AdDecisionServerUrl,
+		AvailSupression:                        m.AvailSupression,
+		Bumper:                                 m.Bumper,
+		CdnConfiguration:                       m.CdnConfiguration,
+		ConfigurationAliases:                   m.ConfigurationAliases,
+		DashConfiguration:                      m.DashConfiguration,
+		HlsConfiguration:                       m.HlsConfiguration,
+		HlsConfigurationManifestEndpointPrefix: m.HlsConfigurationManifestEndpointPrefix,
+		LogConfiguration:                       m.LogConfiguration,
+		LogConfigurationPercentEnabled:         m.LogConfigurationPercentEnabled,
+		LivePreRollConfiguration:               m.LivePreRollConfiguration,
+		ManifestProcessingRules:                m.ManifestProcessingRules,
+		Name:                                   m.Name,
+		PersonalizationThresholdSeconds:        m.PersonalizationThresholdSeconds,
+		PlaybackConfigurationArn:               m.PlaybackConfigurationArn,
+		PlaybackEndpointPrefix:                 m.PlaybackEndpointPrefix,
+		SessionInitializationEndpointPrefix:    m.SessionInitializationEndpointPrefix,
+		SlateAdUrl:                             m.SlateAdUrl,
+		Tags:                                   m.Tags,
+		TranscodeProfileName:                   m.TranscodeProfileName,
+		VideoContentSourceUrl:                  m.VideoContentSourceUrl,
+	}
+}
+
+func newPlaybackConfigurationDataSourceModel(m playbackConfigurationModel, dataSourceTimeouts datasourcetimeouts.Value) playbackConfigurationDataSourceModel {
+	return playbackConfigurationDataSourceModel{
+		ID:                                     m.ID,
+		AdDecisionServerUrl:                    m.AdDecisionServerUrl,
+		AvailSupression:                        m.AvailSupression,
+		Bumper:                                 m.Bumper,
+		CdnConfiguration:                       m.CdnConfiguration,
+		ConfigurationAliases:                   m.ConfigurationAliases,
+		DashConfiguration:                      m.DashConfiguration,
+		HlsConfiguration:                       m.HlsConfiguration,
+		HlsConfigurationManifestEndpointPrefix: m.HlsConfigurationManifestEndpointPrefix,
+		LogConfiguration:                       m.LogConfiguration,
+		LogConfigurationPercentEnabled:         m.LogConfigurationPercentEnabled,
+		LivePreRollConfiguration:               m.LivePreRollConfiguration,
+		ManifestProcessingRules:                m.ManifestProcessingRules,
+		Name:                                   m.Name,
+		PersonalizationThresholdSeconds:        m.PersonalizationThresholdSeconds,
+		PlaybackConfigurationArn:               m.PlaybackConfigurationArn,
+		PlaybackEndpointPrefix:                 m.PlaybackEndpointPrefix,
+		SessionInitializationEndpointPrefix:    m.SessionInitializationEndpointPrefix,
+		SlateAdUrl:                             m.SlateAdUrl,
+		Tags:                                   m.Tags,
+		TranscodeProfileName:                   m.TranscodeProfileName,
+		VideoContentSourceUrl:                  m.VideoContentSourceUrl,
+		Timeouts:                               dataSourceTimeouts,
+	}
+}
+
+var hlsConfigurationAttributeTypes = map[string]attr.Type{
+	"manifest_endpoint_prefix": types.StringType,
+}
+
+var logConfigurationAttributeTypes = map[string]attr.Type{
+	"percent_enabled": types.Int64Type,
 }
 
 type availSupressionModel struct {
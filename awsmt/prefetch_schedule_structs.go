@@ -0,0 +1,40 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type prefetchSchedulesModel struct {
+	ID                        types.String            `tfsdk:"id"`
+	PlaybackConfigurationName *string                 `tfsdk:"playback_configuration_name"`
+	PrefetchSchedules         []prefetchScheduleModel `tfsdk:"prefetch_schedules"`
+	StreamId                  *string                 `tfsdk:"stream_id"`
+	Timeouts                  timeouts.Value          `tfsdk:"timeouts"`
+}
+
+type prefetchScheduleModel struct {
+	Arn                       *string                   `tfsdk:"arn"`
+	Consumption               *prefetchConsumptionModel `tfsdk:"consumption"`
+	Name                      *string                   `tfsdk:"name"`
+	PlaybackConfigurationName *string                   `tfsdk:"playback_configuration_name"`
+	Retrieval                 *prefetchRetrievalModel   `tfsdk:"retrieval"`
+	StreamId                  *string                   `tfsdk:"stream_id"`
+}
+
+type prefetchConsumptionModel struct {
+	AvailMatchingCriteria []availMatchingCriteriaModel `tfsdk:"avail_matching_criteria"`
+	EndTime               timestamp                    `tfsdk:"end_time"`
+	StartTime             timestamp                    `tfsdk:"start_time"`
+}
+
+type availMatchingCriteriaModel struct {
+	DynamicVariable *string `tfsdk:"dynamic_variable"`
+	Operator        *string `tfsdk:"operator"`
+}
+
+type prefetchRetrievalModel struct {
+	DynamicVariables map[string]*string `tfsdk:"dynamic_variables"`
+	EndTime          timestamp          `tfsdk:"end_time"`
+	StartTime        timestamp          `tfsdk:"start_time"`
+}
@@ -0,0 +1,62 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type programModel struct {
+	ID                 types.String    `tfsdk:"id"`
+	AdBreaks           []adBreakModel  `tfsdk:"ad_breaks"`
+	Arn                types.String    `tfsdk:"arn"`
+	ChannelName        *string         `tfsdk:"channel_name"`
+	ClipRange          *clipRangeModel `tfsdk:"clip_range"`
+	CreationTime       timestamp       `tfsdk:"creation_time"`
+	DurationMillis     *int64          `tfsdk:"duration_millis"`
+	LiveSourceName     *string         `tfsdk:"live_source_name"`
+	ProgramName        *string         `tfsdk:"program_name"`
+	ScheduledStartTime timestamp       `tfsdk:"scheduled_start_time"`
+	SourceLocationName *string         `tfsdk:"source_location_name"`
+	VodSourceName      *string         `tfsdk:"vod_source_name"`
+	Timeouts           timeouts.Value  `tfsdk:"timeouts"`
+}
+
+type adBreakModel struct {
+	AdBreakMetadata     []keyValuePairModel       `tfsdk:"ad_break_metadata"`
+	MessageType         *string                   `tfsdk:"message_type"`
+	OffsetMillis        *int64                    `tfsdk:"offset_millis"`
+	Slate               *fillerSlateModel         `tfsdk:"slate"`
+	SpliceInsertMessage *spliceInsertMessageModel `tfsdk:"splice_insert_message"`
+	TimeSignalMessage   *timeSignalMessageModel   `tfsdk:"time_signal_message"`
+}
+
+type keyValuePairModel struct {
+	Key   *string `tfsdk:"key"`
+	Value *string `tfsdk:"value"`
+}
+
+type spliceInsertMessageModel struct {
+	AvailNum        *int64 `tfsdk:"avail_num"`
+	AvailsExpected  *int64 `tfsdk:"avails_expected"`
+	SpliceEventId   *int64 `tfsdk:"splice_event_id"`
+	UniqueProgramId *int64 `tfsdk:"unique_program_id"`
+}
+
+type timeSignalMessageModel struct {
+	SegmentationDescriptors []segmentationDescriptorModel `tfsdk:"segmentation_descriptors"`
+}
+
+type segmentationDescriptorModel struct {
+	SegmentNum           *int64  `tfsdk:"segment_num"`
+	SegmentationEventId  *int64  `tfsdk:"segmentation_event_id"`
+	SegmentationTypeId   *int64  `tfsdk:"segmentation_type_id"`
+	SegmentationUpid     *string `tfsdk:"segmentation_upid"`
+	SegmentationUpidType *int64  `tfsdk:"segmentation_upid_type"`
+	SegmentsExpected     *int64  `tfsdk:"segments_expected"`
+	SubSegmentNum        *int64  `tfsdk:"sub_segment_num"`
+	SubSegmentsExpected  *int64  `tfsdk:"sub_segments_expected"`
+}
+
+type clipRangeModel struct {
+	EndOffsetMillis *int64 `tfsdk:"end_offset_millis"`
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,20 +16,35 @@ import (
 )
 
 var (
-	_ provider.Provider = &awsmtProvider{}
+	_ provider.Provider              = &awsmtProvider{}
+	_ provider.ProviderWithFunctions = &awsmtProvider{}
 )
 
 func New() provider.Provider {
 	return &awsmtProvider{}
 }
 
-type awsmtProvider struct{}
+// newWithClient returns a provider that hands the given client to resources and data sources instead of connecting
+// to AWS. It is used to run the provider against the in-memory fake.
+func newWithClient(client mediaTailorClient) provider.Provider {
+	return &awsmtProvider{client: client}
+}
+
+type awsmtProvider struct {
+	// client overrides the MediaTailor client created in Configure when set.
+	client mediaTailorClient
+}
 
 type awsmtProviderModel struct {
-	Profile types.String `tfsdk:"profile"`
-	Region  types.String `tfsdk:"region"`
+	Profile  types.String `tfsdk:"profile"`
+	Region   types.String `tfsdk:"region"`
+	Endpoint types.String `tfsdk:"endpoint"`
 }
 
+// endpointEnvVar names the environment variable that overrides the MediaTailor endpoint when the provider
+// configuration does not set one.
+const endpointEnvVar = "AWS_ENDPOINT_URL_MEDIATAILOR"
+
 func (p *awsmtProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "awsmt"
 }
@@ -44,6 +60,10 @@ func (p *awsmtProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:    true,
 				Description: "AWS region. defaults to 'eu-central-1'.",
 			},
+			"endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the MediaTailor API, for example of a local emulator. Defaults to the '" + endpointEnvVar + "' environmental variable, or to the AWS endpoint of the region.",
+			},
 		},
 	}
 }
@@ -58,10 +78,17 @@ func (p *awsmtProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
+	if p.client != nil {
+		resp.DataSourceData = p.client
+		resp.ResourceData = p.client
+		tflog.Info(ctx, "Using the preconfigured MediaTailor client")
+		return
+	}
+
 	var region = "eu-central-1"
 	var profile = ""
 
-	if !config.Region.IsUnknown() || !config.Region.IsNull() {
+	if !config.Region.IsUnknown() && !config.Region.IsNull() {
 		region = config.Region.ValueString()
 	}
 
@@ -76,25 +103,38 @@ func (p *awsmtProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		profile = config.Profile.ValueString()
 	}
 
-	tflog.Debug(ctx, "Creating AWS client session")
+	endpoint := os.Getenv(endpointEnvVar)
+	if !config.Endpoint.IsNull() && config.Endpoint.ValueString() != "" {
+		endpoint = config.Endpoint.ValueString()
+	}
 
-	if profile != "" {
-		sess, err = session.NewSessionWithOptions(session.Options{
-			SharedConfigState: session.SharedConfigEnable,
-			Config: aws.Config{
-				Region: aws.String(region),
-			},
-			Profile: profile,
-		})
-	} else {
-		sess, err = session.NewSession(&aws.Config{Region: aws.String(region)})
+	rec, err := recorderFromEnv()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Initialize the API Recorder", err.Error())
+		return
+	}
+	if rec != nil && rec.mode == recorderModeReplay {
+		// Replayed requests never reach AWS, so neither a profile nor credentials are needed.
+		profile = ""
 	}
 
+	tflog.Debug(ctx, "Creating AWS client session")
+
+	sess, err = newSession(region, profile, endpoint)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to Initialize Provider in Region", "unable to initialize provider in the specified region: "+err.Error())
 		return
 	}
 
+	if rec != nil {
+		rec.install(sess.Config)
+		tflog.Info(ctx, "Sending MediaTailor requests through the API recorder", map[string]any{"mode": rec.mode, "cassette": rec.path})
+	}
+
+	sess.Handlers.Validate.PushFrontNamed(startRequestSpanHandler)
+	sess.Handlers.Complete.PushBackNamed(logRequestHandler)
+	sess.Handlers.Complete.PushBackNamed(endRequestSpanHandler)
+
 	c := mediatailor.New(sess)
 
 	resp.DataSourceData = c
@@ -103,13 +143,33 @@ func (p *awsmtProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	tflog.Info(ctx, "AWS MediaTailor client configured", map[string]any{"success": true})
 }
 
+// newSession creates an AWS session for the given region, using the SSO profile if one is given. A non-empty endpoint
+// replaces the AWS endpoint of the region.
+func newSession(region string, profile string, endpoint string) (*session.Session, error) {
+	config := aws.Config{Region: aws.String(region)}
+	if endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+	}
+	if profile != "" {
+		return session.NewSessionWithOptions(session.Options{
+			SharedConfigState: session.SharedConfigEnable,
+			Config:            config,
+			Profile:           profile,
+		})
+	}
+	return session.NewSession(&config)
+}
+
 func (p *awsmtProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		DataSourceChannel,
+		DataSourceChannelPolicyDocument,
 		DataSourceSourceLocation,
 		DataSourcePlaybackConfiguration,
 		DataSourceLiveSource,
 		DataSourceVodSource,
+		DataSourceProgram,
+		DataSourcePrefetchSchedules,
 	}
 
 }
@@ -123,3 +183,15 @@ func (p *awsmtProvider) Resources(_ context.Context) []func() resource.Resource
 		ResourceVodSource,
 	}
 }
+
+func (p *awsmtProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		FunctionChannelArn,
+		FunctionPlaybackConfigurationArn,
+		FunctionSourceLocationArn,
+		FunctionVodSourceArn,
+		FunctionLiveSourceArn,
+		FunctionParseArn,
+		FunctionRenderAdsUrl,
+	}
+}
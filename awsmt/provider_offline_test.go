@@ -0,0 +1,306 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// checkFake runs the given check against the in-memory fake between two Terraform commands.
+func checkFake(fake *fakeMediaTailor, check func() error) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		return check()
+	}
+}
+
+// outputNamed returns the channel output with the given manifest name.
+func outputNamed(outputs []*mediatailor.ResponseOutputItem, manifestName string) *mediatailor.ResponseOutputItem {
+	for _, output := range outputs {
+		if *output.ManifestName == manifestName {
+			return output
+		}
+	}
+	return &mediatailor.ResponseOutputItem{}
+}
+
+// hclTags renders tags as an HCL object, or null without tags.
+func hclTags(tags map[string]string) string {
+	if tags == nil {
+		return "null"
+	}
+	var keys []string
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s = %q", key, tags[key]))
+	}
+	return "{ " + strings.Join(pairs, ", ") + " }"
+}
+
+// offlineChannel returns the configuration of a LOOP channel named test with a DASH and an HLS output. The manifest
+// window of the DASH output is left to its default when manifestWindowSeconds is 0.
+func offlineChannel(state string, manifestWindowSeconds int, tags map[string]string) string {
+	return offlineChannelWithFillerSlate(state, manifestWindowSeconds, tags, "null")
+}
+
+func offlineChannelWithFillerSlate(state string, manifestWindowSeconds int, tags map[string]string, fillerSlate string) string {
+	dashPlaylistSettings := "{}"
+	if manifestWindowSeconds != 0 {
+		dashPlaylistSettings = fmt.Sprintf("{ manifest_window_seconds = %d }", manifestWindowSeconds)
+	}
+	return fmt.Sprintf(`resource "awsmt_channel" "test" {
+							name          = "test"
+							channel_state = "%[1]s"
+							filler_slate  = %[4]s
+							outputs = [
+								{
+									manifest_name          = "default"
+									source_group           = "default"
+									dash_playlist_settings = %[2]s
+								},
+								{
+									manifest_name         = "hls"
+									source_group          = "default"
+									hls_playlist_settings = {}
+								}
+							]
+							playback_mode = "LOOP"
+							policy = jsonencode({
+								Version = "2012-10-17"
+								Statement = [{
+									Sid       = "AllowAnonymous"
+									Effect    = "Allow"
+									Principal = "*"
+									Action    = "mediatailor:GetManifest"
+									Resource  = "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"
+								}]
+							})
+							tags = %[3]s
+						}
+						`, state, dashPlaylistSettings, hclTags(tags), fillerSlate)
+}
+
+func TestOfflineChannelLifecycle(t *testing.T) {
+	fake := newFakeMediaTailor("eu-central-1", "123456789012")
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(fake),
+		CheckDestroy: checkFake(fake, func() error {
+			if len(fake.channels) != 0 || len(fake.channelPolicies) != 0 {
+				return fmt.Errorf("expected the channel and its policy to be deleted")
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: offlineChannel("RUNNING", 60, map[string]string{"Environment": "dev"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_channel.test", "arn", "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"),
+					checkFake(fake, func() error {
+						if got := *fake.channels["test"].ChannelState; got != "RUNNING" {
+							return fmt.Errorf("expected the channel to be started, got %s", got)
+						}
+						if _, ok := fake.channelPolicies["test"]; !ok {
+							return fmt.Errorf("expected the channel policy to be created")
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: offlineChannel("RUNNING", 120, map[string]string{"Environment": "prod", "Team": "video"}),
+				Check: checkFake(fake, func() error {
+					stored := fake.channels["test"]
+					if got := *stored.ChannelState; got != "RUNNING" {
+						return fmt.Errorf("expected the channel to be restarted after the update, got %s", got)
+					}
+					if got := *outputNamed(stored.Outputs, "default").DashPlaylistSettings.ManifestWindowSeconds; got != 120 {
+						return fmt.Errorf("expected the manifest window to be updated, got %d", got)
+					}
+					if len(stored.Tags) != 2 || *stored.Tags["Environment"] != "prod" {
+						return fmt.Errorf("unexpected tags %v", stored.Tags)
+					}
+					return nil
+				}),
+			},
+			{
+				Config: offlineChannel("STOPPED", 0, nil),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_channel.test", "outputs.*", map[string]string{
+						"manifest_name": "default",
+						"dash_playlist_settings.manifest_window_seconds": fmt.Sprint(defaultManifestWindowSeconds),
+					}),
+					checkFake(fake, func() error {
+						stored := fake.channels["test"]
+						if got := *stored.ChannelState; got != "STOPPED" {
+							return fmt.Errorf("expected the channel to be stopped, got %s", got)
+						}
+						if got := *outputNamed(stored.Outputs, "default").DashPlaylistSettings.ManifestWindowSeconds; got != defaultManifestWindowSeconds {
+							return fmt.Errorf("expected the manifest window to be reset to its default, got %d", got)
+						}
+						if len(stored.Tags) != 0 {
+							return fmt.Errorf("expected the tags to be removed, got %v", stored.Tags)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestOfflineChannelCreateError(t *testing.T) {
+	fake := newFakeMediaTailor("eu-central-1", "123456789012")
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(fake),
+		CheckDestroy: checkFake(fake, func() error {
+			if len(fake.channels) != 0 {
+				return fmt.Errorf("expected no channel to be created")
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			{
+				// The error is reported on the filler_slate attribute, so Terraform shows its line.
+				Config:      offlineChannelWithFillerSlate("STOPPED", 60, nil, `{ source_location_name = "test", vod_source_name = "slate" }`),
+				ExpectError: regexp.MustCompile(`(?s)Error while creating channel.*\d+:\s+filler_slate\s+=`),
+			},
+		},
+	})
+}
+
+func offlineSourceLocation(baseUrl string, tags map[string]string) string {
+	return fmt.Sprintf(`resource "awsmt_source_location" "test" {
+							name = "test_source_location"
+							http_configuration = {
+								base_url = "%[1]s"
+							}
+							segment_delivery_configurations = [{
+								base_url = "https://example.com/"
+								name     = "default"
+							}]
+							tags = %[2]s
+						}
+						`, baseUrl, hclTags(tags))
+}
+
+func offlineSource(resourceType, name, path string) string {
+	return fmt.Sprintf(`resource "%[1]s" "%[2]s" {
+							name                 = "%[2]s"
+							source_location_name = awsmt_source_location.test.name
+							http_package_configurations = [{
+								path         = "%[3]s"
+								source_group = "default"
+								type         = "HLS"
+							}]
+						}
+						`, resourceType, name, path)
+}
+
+func TestOfflineSourceLocationLifecycle(t *testing.T) {
+	fake := newFakeMediaTailor("eu-central-1", "123456789012")
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(fake),
+		CheckDestroy: checkFake(fake, func() error {
+			if len(fake.sourceLocations) != 0 || len(fake.vodSources) != 0 || len(fake.liveSources) != 0 {
+				return fmt.Errorf("expected the source location and its sources to be deleted")
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: offlineSourceLocation("https://example.com", map[string]string{"Environment": "dev"}) +
+					offlineSource("awsmt_vod_source", "vod", "/vod") +
+					offlineSource("awsmt_live_source", "live", "/live"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_vod_source.vod", "arn", "arn:aws:mediatailor:eu-central-1:123456789012:vodSource/test_source_location/vod"),
+					resource.TestCheckResourceAttr("awsmt_live_source.live", "arn", "arn:aws:mediatailor:eu-central-1:123456789012:liveSource/test_source_location/live"),
+				),
+			},
+			{
+				Config: offlineSourceLocation("https://example.com", map[string]string{"Environment": "dev"}) +
+					offlineSource("awsmt_vod_source", "vod", "/updated") +
+					offlineSource("awsmt_live_source", "live", "/live"),
+				Check: checkFake(fake, func() error {
+					if got := *fake.vodSources["test_source_location"]["vod"].HttpPackageConfigurations[0].Path; got != "/updated" {
+						return fmt.Errorf("expected the VOD source path to be updated, got %s", got)
+					}
+					return nil
+				}),
+			},
+			{
+				Config: offlineSourceLocation("https://updated.example.com", nil) +
+					offlineSource("awsmt_vod_source", "vod", "/updated") +
+					offlineSource("awsmt_live_source", "live", "/live"),
+				Check: checkFake(fake, func() error {
+					stored := fake.sourceLocations["test_source_location"]
+					if got := *stored.HttpConfiguration.BaseUrl; got != "https://updated.example.com" {
+						return fmt.Errorf("expected the base URL to be updated, got %s", got)
+					}
+					if len(stored.Tags) != 0 {
+						return fmt.Errorf("expected the tags to be removed, got %v", stored.Tags)
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
+
+func offlinePlaybackConfiguration(adDecisionServerUrl string, tags map[string]string) string {
+	return fmt.Sprintf(`resource "awsmt_playback_configuration" "test" {
+							name                     = "test"
+							ad_decision_server_url   = "%[1]s"
+							video_content_source_url = "https://example.com/content"
+							dash_configuration = {
+								origin_manifest_type = "SINGLE_PERIOD"
+							}
+							tags = %[2]s
+						}
+						`, adDecisionServerUrl, hclTags(tags))
+}
+
+func TestOfflinePlaybackConfigurationLifecycle(t *testing.T) {
+	fake := newFakeMediaTailor("eu-central-1", "123456789012")
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(fake),
+		CheckDestroy: checkFake(fake, func() error {
+			if len(fake.playbackConfigurations) != 0 {
+				return fmt.Errorf("expected the playback configuration to be deleted")
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: offlinePlaybackConfiguration("https://example.com/ads", map[string]string{"Environment": "dev"}),
+				Check:  resource.TestCheckResourceAttrSet("awsmt_playback_configuration.test", "playback_endpoint_prefix"),
+			},
+			{
+				Config: offlinePlaybackConfiguration("https://example.com/other-ads", map[string]string{"Environment": "prod"}),
+				Check: checkFake(fake, func() error {
+					stored := fake.playbackConfigurations["test"]
+					if got := *stored.AdDecisionServerUrl; got != "https://example.com/other-ads" {
+						return fmt.Errorf("expected the ad decision server URL to be updated, got %s", got)
+					}
+					if len(stored.Tags) != 1 || *stored.Tags["Environment"] != "prod" {
+						return fmt.Errorf("unexpected tags %v", stored.Tags)
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
@@ -1,8 +1,17 @@
 package awsmt
 
 import (
+	"context"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"os/exec"
+	"testing"
 )
 
 var (
@@ -11,6 +20,55 @@ var (
 	}
 )
 
-/* func TestMain(m *testing.M) {
+// testUnitProtoV6ProviderFactories returns provider factories for unit tests, whose provider sends its requests to
+// the given client instead of AWS.
+func testUnitProtoV6ProviderFactories(client mediaTailorClient) map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"awsmt": providerserver.NewProtocol6WithError(newWithClient(client)),
+	}
+}
+
+// testUnitPreCheck skips a unit test that runs Terraform when no Terraform CLI is available. resource.UnitTest would
+// otherwise try to download the latest version, and exit the test binary if it cannot.
+func testUnitPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("Terraform must be in the PATH, or TF_ACC_TERRAFORM_PATH or TF_ACC_TERRAFORM_VERSION must be set, for unit tests that run Terraform")
+	}
+}
+
+func TestProviderRegion(t *testing.T) {
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv(recorderModeEnvVar, "")
+	t.Setenv(endpointEnvVar, "")
+	ctx := context.Background()
+	p := New()
+	schemaResp := provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	for configured, expected := range map[string]string{"": "eu-central-1", "us-east-1": "us-east-1"} {
+		region := tftypes.NewValue(tftypes.String, nil)
+		if configured != "" {
+			region = tftypes.NewValue(tftypes.String, configured)
+		}
+		raw := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"endpoint": tftypes.NewValue(tftypes.String, nil),
+			"profile":  tftypes.NewValue(tftypes.String, nil),
+			"region":   region,
+		})
+		resp := provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		if got := *resp.ResourceData.(*mediatailor.MediaTailor).Config.Region; got != expected {
+			t.Errorf("expected the region %s for the configured region %q, got %s", expected, configured, got)
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
 	resource.TestMain(m)
-} */
+}
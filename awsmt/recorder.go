@@ -0,0 +1,274 @@
+package awsmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// @ADR
+// Context: Acceptance tests hit MediaTailor for every step and take minutes, and cannot run without AWS credentials.
+// Decision: We decided to record the HTTP traffic of the MediaTailor client to cassette files on request and to replay
+// it in place of AWS. The recorder is an http.RoundTripper installed in Configure, and is enabled only through
+// environment variables, so provider users are never affected.
+// Consequences: Cassettes only hold the request method, path, query and body and the response status, body and
+// request ID. Account IDs are scrubbed from both, and restored from AWS_ACCOUNT_ID on replay. Recording requires
+// AWS_ACCOUNT_ID, as account IDs outside of ARNs, such as in policies, cannot be recognized otherwise. A cassette has
+// to be recorded again whenever a test or the requests the provider sends change.
+
+const (
+	// recorderModeEnvVar selects the recorder mode, either recorderModeRecord or recorderModeReplay.
+	recorderModeEnvVar = "AWSMT_RECORDER_MODE"
+	// recorderCassetteEnvVar names the cassette file to record to or to replay from.
+	recorderCassetteEnvVar = "AWSMT_RECORDER_CASSETTE"
+	recorderModeRecord     = "record"
+	recorderModeReplay     = "replay"
+	// recorderAccountID replaces account IDs in cassettes.
+	recorderAccountID = "123456789012"
+)
+
+// accountIDPattern matches an AWS account ID.
+var accountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// arnAccountIDPattern matches the account ID of an ARN.
+var arnAccountIDPattern = regexp.MustCompile(`(arn:[\w-]+:[\w-]+:[\w-]*:)\d{12}(:)`)
+
+// recorderResponseHeaders are the response headers that are kept in cassettes.
+var recorderResponseHeaders = []string{"Content-Type", "X-Amzn-Errortype", "X-Amzn-Requestid"}
+
+type cassette struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+	used     bool
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// recorder records the requests sent through it to a cassette, or answers them from a cassette.
+type recorder struct {
+	mode      string
+	path      string
+	accountID string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette cassette
+}
+
+var (
+	recordersMu sync.Mutex
+	// recorders shares one recorder per cassette between the clients that Configure creates during a test.
+	recorders = map[string]*recorder{}
+)
+
+// recorderFromEnv returns the recorder selected by the environment, or nil if recording is disabled.
+func recorderFromEnv() (*recorder, error) {
+	mode := os.Getenv(recorderModeEnvVar)
+	if mode == "" {
+		return nil, nil
+	}
+	if mode != recorderModeRecord && mode != recorderModeReplay {
+		return nil, fmt.Errorf("%s must be either %q or %q, got %q", recorderModeEnvVar, recorderModeRecord, recorderModeReplay, mode)
+	}
+	path := os.Getenv(recorderCassetteEnvVar)
+	if path == "" {
+		return nil, fmt.Errorf("%s must be set when %s is set", recorderCassetteEnvVar, recorderModeEnvVar)
+	}
+	return openRecorder(mode, path, os.Getenv("AWS_ACCOUNT_ID"))
+}
+
+// openRecorder returns the recorder of the given cassette. Recording starts with an empty cassette, replaying loads
+// the cassette from disk.
+func openRecorder(mode, path, accountID string) (*recorder, error) {
+	if mode == recorderModeRecord && !accountIDPattern.MatchString(accountID) {
+		return nil, fmt.Errorf("AWS_ACCOUNT_ID must be set to the 12-digit ID of the recorded account, so that it can be scrubbed from the cassette, got %q", accountID)
+	}
+
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+
+	if r, ok := recorders[path]; ok && r.mode == mode {
+		return r, nil
+	}
+	r := &recorder{mode: mode, path: path, accountID: accountID, transport: http.DefaultTransport}
+	if mode == recorderModeReplay {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette: %w", err)
+		}
+		if err := json.Unmarshal(raw, &r.cassette); err != nil {
+			return nil, fmt.Errorf("error parsing cassette %s: %w", path, err)
+		}
+	}
+	recorders[path] = r
+	return r, nil
+}
+
+// install sends the requests of clients created with the given configuration through the recorder. When replaying,
+// it also replaces the credentials, as no request reaches AWS.
+func (r *recorder) install(config *aws.Config) {
+	if config.HTTPClient != nil && config.HTTPClient.Transport != nil {
+		r.transport = config.HTTPClient.Transport
+	}
+	config.HTTPClient = &http.Client{Transport: r}
+	if r.mode == recorderModeReplay {
+		config.Credentials = credentials.NewStaticCredentials("replay", "replay", "")
+	}
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == recorderModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *recorder) record(req *http.Request, recorded recordedRequest) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	headers := map[string]string{}
+	for _, name := range recorderResponseHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &interaction{
+		Request:  recorded,
+		Response: recordedResponse{Status: resp.StatusCode, Headers: headers, Body: r.scrub(string(body))},
+	})
+	// The cassette is written after every interaction, so that a test that fails halfway still leaves one behind.
+	return resp, r.save()
+}
+
+// replay answers the request with the first unused interaction that matches it, so that repeated requests, such as
+// the polls of a waiter, are answered in the recorded order.
+func (r *recorder) replay(req *http.Request, recorded recordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, i := range r.cassette.Interactions {
+		if i.used || i.Request != recorded {
+			continue
+		}
+		i.used = true
+		resp := &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.Status, http.StatusText(i.Response.Status)),
+			StatusCode:    i.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          io.NopCloser(strings.NewReader(r.restore(i.Response.Body))),
+			ContentLength: int64(len(r.restore(i.Response.Body))),
+			Request:       req,
+		}
+		for name, value := range i.Response.Headers {
+			resp.Header.Set(name, value)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("cassette %s has no unused interaction for %s %s", r.path, recorded.Method, recorded.Path)
+}
+
+// recordRequest returns the scrubbed request as stored in cassettes, and leaves the request body readable.
+func (r *recorder) recordRequest(req *http.Request) (recordedRequest, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return recordedRequest{}, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return recordedRequest{
+		Method: req.Method,
+		Path:   r.scrub(req.URL.Path),
+		Query:  r.scrub(req.URL.Query().Encode()),
+		Body:   r.scrub(canonicalJSON(body)),
+	}, nil
+}
+
+// scrub replaces account IDs with recorderAccountID.
+func (r *recorder) scrub(value string) string {
+	if r.accountID != "" {
+		value = strings.ReplaceAll(value, r.accountID, recorderAccountID)
+	}
+	return arnAccountIDPattern.ReplaceAllString(value, "${1}"+recorderAccountID+"${2}")
+}
+
+// restore replaces the scrubbed account IDs with the account ID of the replaying environment, if it is known.
+func (r *recorder) restore(value string) string {
+	if r.accountID == "" {
+		return value
+	}
+	return strings.ReplaceAll(value, recorderAccountID, r.accountID)
+}
+
+func (r *recorder) save() error {
+	raw, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("error creating cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(raw, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error writing cassette: %w", err)
+	}
+	return nil
+}
+
+// canonicalJSON returns the body with sorted keys and without whitespace, so that bodies match regardless of how
+// they were encoded. Bodies that are not JSON are returned as they are.
+func canonicalJSON(body []byte) string {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return string(body)
+	}
+	canonical, err := json.Marshal(decoded)
+	if err != nil {
+		return string(body)
+	}
+	return string(canonical)
+}
@@ -0,0 +1,111 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newRecordedClient returns an SDK client that sends its requests to the endpoint through the given recorder.
+func newRecordedClient(t *testing.T, rec *recorder, endpoint string) *mediatailor.MediaTailor {
+	t.Helper()
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("eu-central-1"),
+		Endpoint:    aws.String(endpoint),
+		Credentials: credentials.NewStaticCredentials("AKIDSECRET", "very-secret", "session-token"),
+		MaxRetries:  aws.Int(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.install(sess.Config)
+	return mediatailor.New(sess)
+}
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	server := httptest.NewServer(NewEmulator("eu-central-1", "111122223333"))
+
+	rec, err := openRecorder(recorderModeRecord, path, "111122223333")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newRecordedClient(t, rec, server.URL)
+	created, err := client.CreateChannel(fakeChannelInput("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *created.Arn; got != "arn:aws:mediatailor:eu-central-1:111122223333:channel/test" {
+		t.Errorf("expected the recorded response to be passed on unchanged, got %s", got)
+	}
+	if _, err := client.ListTagsForResource(&mediatailor.ListTagsForResourceInput{ResourceArn: created.Arn}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: aws.String("missing")})
+	requireErrorCode(t, err, fakeErrCodeNotFound)
+	server.Close()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"111122223333", "AKIDSECRET", "very-secret", "session-token"} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("expected %s to be scrubbed from the cassette", secret)
+		}
+	}
+
+	// The emulator is gone, so every response has to come from the cassette.
+	rec, err = openRecorder(recorderModeReplay, path, "444455556666")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = newRecordedClient(t, rec, server.URL)
+	created, err = client.CreateChannel(fakeChannelInput("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *created.Arn; got != "arn:aws:mediatailor:eu-central-1:444455556666:channel/test" {
+		t.Errorf("expected the account ID to be restored, got %s", got)
+	}
+	if _, err := client.ListTagsForResource(&mediatailor.ListTagsForResourceInput{ResourceArn: created.Arn}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: aws.String("missing")})
+	requireErrorCode(t, err, fakeErrCodeNotFound)
+
+	if _, err := client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: aws.String("missing")}); err == nil || !strings.Contains(err.Error(), "no unused interaction") {
+		t.Errorf("expected a request that was not recorded to fail, got %v", err)
+	}
+}
+
+func TestRecorderFromEnv(t *testing.T) {
+	t.Setenv(recorderModeEnvVar, "")
+	if rec, err := recorderFromEnv(); rec != nil || err != nil {
+		t.Errorf("expected the recorder to be disabled, got %v, %v", rec, err)
+	}
+
+	t.Setenv(recorderModeEnvVar, "rewind")
+	if _, err := recorderFromEnv(); err == nil {
+		t.Error("expected an unknown mode to be rejected")
+	}
+
+	t.Setenv(recorderModeEnvVar, recorderModeRecord)
+	t.Setenv(recorderCassetteEnvVar, filepath.Join(t.TempDir(), "unscrubbed.json"))
+	t.Setenv("AWS_ACCOUNT_ID", "")
+	if _, err := recorderFromEnv(); err == nil {
+		t.Error("expected recording without an account ID to be rejected")
+	}
+
+	t.Setenv(recorderModeEnvVar, recorderModeReplay)
+	t.Setenv(recorderCassetteEnvVar, filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := recorderFromEnv(); err == nil {
+		t.Error("expected a missing cassette to be rejected")
+	}
+}
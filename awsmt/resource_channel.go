@@ -3,38 +3,82 @@ package awsmt
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
-	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
-	"strings"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &resourceChannel{}
-	_ resource.ResourceWithConfigure   = &resourceChannel{}
-	_ resource.ResourceWithImportState = &resourceChannel{}
+	_ resource.Resource                 = &resourceChannel{}
+	_ resource.ResourceWithConfigure    = &resourceChannel{}
+	_ resource.ResourceWithImportState  = &resourceChannel{}
+	_ resource.ResourceWithUpgradeState = &resourceChannel{}
 )
 
+// The values MediaTailor uses for settings of a channel that are not part of the request. The schema declares them as
+// defaults, so that unset settings do not show up as drift once the channel has been read back.
+const (
+	defaultChannelTier                       = "BASIC"
+	defaultAdMarkupType                      = "DATERANGE"
+	defaultManifestWindowSeconds             = 60
+	defaultMinBufferTimeSeconds              = 30
+	defaultMinUpdatePeriodSeconds            = 2
+	defaultSuggestedPresentationDelaySeconds = 25
+)
+
+// channelErrorFields maps the fields named in MediaTailor errors to the channel attributes. Output settings are
+// reported on outputs, as the elements of a set cannot be addressed by index.
+var channelErrorFields = apiErrorFields{
+	"ChannelName":          path.Root("name"),
+	"FillerSlate":          path.Root("filler_slate"),
+	"Output":               path.Root("outputs"),
+	"ManifestName":         path.Root("outputs"),
+	"SourceGroup":          path.Root("outputs"),
+	"HlsPlaylistSettings":  path.Root("outputs"),
+	"DashPlaylistSettings": path.Root("outputs"),
+	"PlaybackMode":         path.Root("playback_mode"),
+	"Policy":               path.Root("policy"),
+	"Tier":                 path.Root("tier"),
+	"Tag":                  path.Root("tags"),
+}
+
+// optionalComputedInt64WithDefault returns an optional integer that takes the given value when it is not configured.
+// It is used in outputs, where schema defaults cannot be used, see defaultInSet.
+func optionalComputedInt64WithDefault(value int64) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:      true,
+		Computed:      true,
+		PlanModifiers: []planmodifier.Int64{defaultInSet("manifest_name", types.Int64Value(value))},
+	}
+}
+
 func ResourceChannel() resource.Resource {
 	return &resourceChannel{}
 }
 
 type resourceChannel struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
 }
 
 func (r *resourceChannel) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_channel"
 }
 
-func (r *resourceChannel) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *resourceChannel) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 2,
 		Attributes: map[string]schema.Attribute{
-			"id":   computedString,
-			"arn":  computedString,
+			"id":   computedStringKeptUnless(path.MatchRoot("name")),
+			"arn":  computedStringKeptUnless(path.MatchRoot("name")),
 			"name": requiredString,
 			// @ADR
 			// Context: We cannot test the deletion of a running channel if we cannot set the channel_state property
@@ -45,11 +89,21 @@ func (r *resourceChannel) Schema(_ context.Context, _ resource.SchemaRequest, re
 			// SDK calls.
 			"channel_state": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
 				Validators: []validator.String{
 					stringvalidator.OneOf("RUNNING", "STOPPED"),
 				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					ignoreChannelStateDrift(),
+				},
+			},
+			"creation_time": computedTimestampKeptUnless(path.MatchRoot("name")),
+			"enforce_channel_state": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
-			"creation_time": computedString,
 			"filler_slate": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
@@ -57,32 +111,50 @@ func (r *resourceChannel) Schema(_ context.Context, _ resource.SchemaRequest, re
 					"vod_source_name":      optionalString,
 				},
 			},
-			"last_modified_time": computedString,
-			"outputs": schema.ListNestedAttribute{
+			"last_modified_time": computedTimestamp,
+			// @ADR
+			// Context: MediaTailor may return outputs in another order than they were configured in, which caused
+			// permanent diffs while outputs were a list.
+			// Decision: We decided to turn outputs, segment delivery configurations and HTTP package configurations into
+			// sets, identified by their natural key, which uniqueKeys enforces.
+			// Consequences: Reordering the configuration produces an empty plan, but computed values of set elements,
+			// such as playback_url, need useStateForUnknownInSet to be matched to their prior value.
+			"outputs": schema.SetNestedAttribute{
 				Required: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"dash_playlist_settings": schema.SingleNestedAttribute{
 							Optional: true,
 							Attributes: map[string]schema.Attribute{
-								"manifest_window_seconds":              optionalInt64,
-								"min_buffer_time_seconds":              optionalInt64,
-								"min_update_period_seconds":            optionalInt64,
-								"suggested_presentation_delay_seconds": optionalInt64,
+								"manifest_window_seconds":              optionalComputedInt64WithDefault(defaultManifestWindowSeconds),
+								"min_buffer_time_seconds":              optionalComputedInt64WithDefault(defaultMinBufferTimeSeconds),
+								"min_update_period_seconds":            optionalComputedInt64WithDefault(defaultMinUpdatePeriodSeconds),
+								"suggested_presentation_delay_seconds": optionalComputedInt64WithDefault(defaultSuggestedPresentationDelaySeconds),
 							},
 						},
 						"hls_playlist_settings": schema.SingleNestedAttribute{
 							Optional: true,
 							Attributes: map[string]schema.Attribute{
-								"ad_markup_type":          optionalList,
-								"manifest_window_seconds": optionalInt64,
+								"ad_markup_type": schema.ListAttribute{
+									Optional:    true,
+									Computed:    true,
+									ElementType: types.StringType,
+									PlanModifiers: []planmodifier.List{defaultInSet("manifest_name", types.ListValueMust(types.StringType, []attr.Value{
+										types.StringValue(defaultAdMarkupType),
+									}))},
+								},
+								"manifest_window_seconds": optionalComputedInt64WithDefault(defaultManifestWindowSeconds),
 							},
 						},
 						"manifest_name": requiredString,
-						"playback_url":  computedString,
-						"source_group":  requiredString,
+						"playback_url": schema.StringAttribute{
+							Computed:      true,
+							PlanModifiers: []planmodifier.String{useStateForUnknownInSet("manifest_name", path.MatchRoot("name"))},
+						},
+						"source_group": requiredString,
 					},
 				},
+				Validators: []validator.Set{uniqueKeys("Output", "manifest_name")},
 			},
 			"playback_mode": schema.StringAttribute{
 				Required: true,
@@ -97,14 +169,17 @@ func (r *resourceChannel) Schema(_ context.Context, _ resource.SchemaRequest, re
 			// Consequences: The CRUD functions for the channel resource now have to perform more than 1 API calls,
 			// increasing the chances of error. Also, and the policy requires the developer to specify the ARN for the channel
 			// it refers to, even if it is not known while declaring the resource, forcing the developer to create the
-			// ARN themselves using the account ID and resource name.
+			// ARN themselves using the account ID and resource name. The channel_arn provider function builds it for them.
 			"policy": schema.StringAttribute{
 				Optional:   true,
-				CustomType: jsontypes.NormalizedType{},
+				CustomType: iamPolicyType{},
 			},
-			"tags": optionalMap,
+			"tags":     optionalMap,
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 			"tier": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultChannelTier),
 				Validators: []validator.String{
 					stringvalidator.OneOf([]string{"BASIC", "STANDARD"}...),
 				},
@@ -118,7 +193,7 @@ func (r *resourceChannel) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.MediaTailor)
+	r.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (r *resourceChannel) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -130,26 +205,42 @@ func (r *resourceChannel) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_channel", "create", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create, defaultCreateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	input := channelInput(plan)
 
-	channel, err := r.client.CreateChannel(&input)
+	channel, err := r.client.CreateChannelWithContext(ctx, &input)
 	if err != nil {
-		resp.Diagnostics.AddError("Error while creating channel "+*input.ChannelName, err.Error())
+		addAPIError(&resp.Diagnostics, "Error while creating channel "+*input.ChannelName, err, channelErrorFields)
 		return
 	}
 
-	if plan.ChannelState != nil && *plan.ChannelState == "RUNNING" {
-		_, err := r.client.StartChannel(&mediatailor.StartChannelInput{ChannelName: plan.Name})
+	if plan.ChannelState.ValueString() == "RUNNING" {
+		_, err := r.client.StartChannelWithContext(ctx, &mediatailor.StartChannelInput{ChannelName: plan.Name})
 		if err != nil {
-			resp.Diagnostics.AddError("Error while starting the channel "+*channel.ChannelName, err.Error())
+			addAPIError(&resp.Diagnostics, "Error while starting the channel "+*channel.ChannelName, err, channelErrorFields)
 			return
 		}
 	}
 
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, channelStatePrivateKey, lastAppliedChannelState(plan.ChannelState))...)
+
+	if plan.ChannelState.IsUnknown() {
+		plan.ChannelState = types.StringPointerValue(channel.ChannelState)
+	}
+
 	if !plan.Policy.IsNull() {
 		policy := plan.Policy.ValueString()
-		if err := createChannelPolicy(plan.Name, &policy, r.client); err != nil {
-			resp.Diagnostics.AddError("Error while creating the channel policy for channel "+*channel.ChannelName, err.Error())
+		if err := createChannelPolicy(ctx, plan.Name, &policy, r.client); err != nil {
+			addAPIError(&resp.Diagnostics, "Error while creating the policy of channel "+*channel.ChannelName, err, channelErrorFields)
 			return
 		}
 	}
@@ -173,33 +264,52 @@ func (r *resourceChannel) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	channel, err := r.client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: state.Name})
+	ctx, span := startResourceSpan(ctx, "awsmt_channel", "read", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	channel, err := r.client.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: state.Name})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while describing channel "+err.Error(),
-			err.Error(),
-		)
+		if isNotFound(err) {
+			// The resource was deleted outside of Terraform, which plans to create it again.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error while describing channel "+*state.Name, err, channelErrorFields)
+		return
 	}
 
-	policy, err := r.client.GetChannelPolicy(&mediatailor.GetChannelPolicyInput{ChannelName: state.Name})
-	if err != nil && !strings.Contains(err.Error(), "NotFound") {
-		resp.Diagnostics.AddError(
-			"Error while getting channel policy "+err.Error(),
-			err.Error(),
-		)
+	policy, err := r.client.GetChannelPolicyWithContext(ctx, &mediatailor.GetChannelPolicyInput{ChannelName: state.Name})
+	if err != nil && !isNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error while getting the policy of channel "+*state.Name, err, channelErrorFields)
+		return
 	}
 
 	if policy.Policy != nil {
-		state.Policy = jsontypes.NewNormalizedPointerValue(policy.Policy)
+		state.Policy = newIamPolicyPointerValue(policy.Policy)
 
 	} else {
-		state.Policy = jsontypes.NewNormalizedNull()
+		state.Policy = newIamPolicyNull()
 	}
 
 	state = readChannelToState(state, *channel)
 
-	if state.ChannelState != nil {
-		state.ChannelState = channel.ChannelState
+	// @ADR
+	// Context: The channel state used to be refreshed only when it was configured, so channels stopped outside of
+	// Terraform went unnoticed.
+	// Decision: We decided to always refresh the channel state, and to let enforce_channel_state decide whether
+	// the drift is reconciled on the next apply.
+	// Consequences: Drift of the channel state is always shown in the plan, even when it is ignored.
+	state.ChannelState = types.StringPointerValue(channel.ChannelState)
+
+	if state.EnforceChannelState.IsNull() {
+		state.EnforceChannelState = types.BoolValue(true)
 	}
 
 	diags = resp.State.Set(ctx, &state)
@@ -218,75 +328,78 @@ func (r *resourceChannel) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_channel", "update", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update, defaultUpdateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	channelName := plan.Name
 
-	channel, err := r.client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: channelName})
+	channel, err := r.client.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: channelName})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while describing channel "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while describing channel "+*channelName, err, channelErrorFields)
+		return
 	}
 
-	err = updatesTags(r.client, channel.Tags, plan.Tags, *channel.Arn)
+	err = updatesTags(ctx, r.client, channel.Tags, plan.Tags, *channel.Arn)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while updating channel tags"+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while updating the tags of channel "+*channelName, err, channelErrorFields)
+		return
 	}
 
 	previousState := channel.ChannelState
-	newState := plan.ChannelState
 
-	err = stopChannel(previousState, channelName, r.client)
+	err = stopChannel(ctx, previousState, channelName, r.client)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while stopping running channel "+*channelName+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while stopping channel "+*channelName, err, channelErrorFields)
+		return
 	}
 
-	oldPolicy, err := r.client.GetChannelPolicy(&mediatailor.GetChannelPolicyInput{ChannelName: channelName})
-	if err != nil && !strings.Contains(err.Error(), "NotFound") {
-		resp.Diagnostics.AddError(
-			"Error while getting channel policy "+err.Error(),
-			err.Error(),
-		)
+	oldPolicy, err := r.client.GetChannelPolicyWithContext(ctx, &mediatailor.GetChannelPolicyInput{ChannelName: channelName})
+	if err != nil && !isNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error while getting the policy of channel "+*channelName, err, channelErrorFields)
+		return
 	}
 
-	policy := jsontypes.NewNormalizedPointerValue(oldPolicy.Policy)
+	policy := newIamPolicyPointerValue(oldPolicy.Policy)
 
 	newPolicy := plan.Policy
 
-	plan, err = updatePolicy(&plan, channelName, policy, newPolicy, r.client)
+	plan, err = updatePolicy(ctx, &plan, channelName, policy, newPolicy, r.client)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while updating channel policy "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while updating the policy of channel "+*channelName, err, channelErrorFields)
+		return
 	}
 
 	var params = getUpdateChannelInput(plan)
-	updatedChannel, err := r.client.UpdateChannel(&params)
+	updatedChannel, err := r.client.UpdateChannelWithContext(ctx, &params)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while updating channel "+*channel.ChannelName+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while updating channel "+*channelName, err, channelErrorFields)
+		return
 	}
 
 	wasRunning := previousState != nil && *previousState == "RUNNING"
-	shouldRun := newState != nil && *newState == "RUNNING"
-	if (newState == nil && wasRunning) || shouldRun {
-		_, err := r.client.StartChannel(&mediatailor.StartChannelInput{ChannelName: channelName})
+	shouldRun := plan.ChannelState.ValueString() == "RUNNING"
+	if (plan.ChannelState.IsUnknown() && wasRunning) || shouldRun {
+		_, err := r.client.StartChannelWithContext(ctx, &mediatailor.StartChannelInput{ChannelName: channelName})
 		if err != nil {
-			resp.Diagnostics.AddError("Error while starting the channel "+*channelName, err.Error())
+			addAPIError(&resp.Diagnostics, "Error while starting the channel "+*channelName, err, channelErrorFields)
 			return
 		}
 	}
 
-	plan.ChannelState = newState
+	if plan.ChannelState.IsUnknown() {
+		plan.ChannelState = types.StringPointerValue(previousState)
+	}
+
+	var configuredState types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("channel_state"), &configuredState)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, channelStatePrivateKey, lastAppliedChannelState(configuredState))...)
 
 	plan = readChannelToPlan(plan, mediatailor.CreateChannelOutput(*updatedChannel))
 
@@ -313,31 +426,54 @@ func (r *resourceChannel) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	if _, err := r.client.StopChannel(&mediatailor.StopChannelInput{ChannelName: state.Name}); err != nil {
-		resp.Diagnostics.AddError(
-			"error while stopping the channel "+err.Error(),
-			err.Error(),
-		)
+	ctx, span := startResourceSpan(ctx, "awsmt_channel", "delete", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete, defaultDeleteTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.StopChannelWithContext(ctx, &mediatailor.StopChannelInput{ChannelName: state.Name}); err != nil {
+		addAPIError(&resp.Diagnostics, "Error while stopping channel "+*state.Name, err, channelErrorFields)
 		return
 	}
 
-	if _, err := r.client.DeleteChannelPolicy(&mediatailor.DeleteChannelPolicyInput{ChannelName: state.Name}); err != nil {
-		resp.Diagnostics.AddError(
-			"error while deleting the channel policy "+err.Error(),
-			err.Error(),
-		)
+	if _, err := r.client.DeleteChannelPolicyWithContext(ctx, &mediatailor.DeleteChannelPolicyInput{ChannelName: state.Name}); err != nil {
+		addAPIError(&resp.Diagnostics, "Error while deleting the policy of channel "+*state.Name, err, channelErrorFields)
 		return
 	}
 
-	if _, err := r.client.DeleteChannel(&mediatailor.DeleteChannelInput{ChannelName: state.Name}); err != nil {
-		resp.Diagnostics.AddError(
-			"error while deleting the channel "+err.Error(),
-			err.Error(),
-		)
+	if _, err := r.client.DeleteChannelWithContext(ctx, &mediatailor.DeleteChannelInput{ChannelName: state.Name}); err != nil {
+		addAPIError(&resp.Diagnostics, "Error while deleting channel "+*state.Name, err, channelErrorFields)
 		return
 	}
 }
 
 func (r *resourceChannel) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	names, err := importNamesFromID(ctx, r.client, req.ID, "channel")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), names[0])...)
+}
+
+func (r *resourceChannel) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeStateJSON(upgradeChannelStateV0, upgradeChannelStateV1)},
+		1: {StateUpgrader: upgradeStateJSON(upgradeChannelStateV1)},
+	}
 }
+
+// upgradeChannelStateV0 sets enforce_channel_state, added in version 1, to its default.
+func upgradeChannelStateV0(state map[string]interface{}) error {
+	state["enforce_channel_state"] = true
+	return nil
+}
+
+// upgradeChannelStateV1 converts the timestamps to RFC 3339, which version 2 stores them as.
+var upgradeChannelStateV1 = upgradeTimestamps("creation_time", "last_modified_time")
@@ -2,20 +2,46 @@ package awsmt
 
 import (
 	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 )
 
 func testAccPreCheck(t *testing.T) {
+	if mode := os.Getenv(recorderModeEnvVar); mode != "" {
+		// Every test records to and replays from its own cassette.
+		cassette := filepath.Join("testdata", "cassettes", t.Name()+".json")
+		t.Setenv(recorderCassetteEnvVar, cassette)
+		if mode == recorderModeReplay {
+			if _, err := os.Stat(cassette); err != nil {
+				t.Fatalf("No cassette to replay %s from, record it with %s=%s: %s", t.Name(), recorderModeEnvVar, recorderModeRecord, err)
+			}
+			return
+		}
+	}
 	if a, b, c := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_PROFILE"); (a == "" || b == "") && c == "" {
 		t.Fatal("Either AWS_PROFILE or both AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set for acceptance tests")
 	}
 }
 
+// testAccImportStateIdFromArn returns the ARN of the given resource, to import it by ARN instead of by name.
+func testAccImportStateIdFromArn(resourceName string, attribute string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return rs.Primary.Attributes[attribute], nil
+	}
+}
+
 func TestAccChannelResourceBasic(t *testing.T) {
-	name := "test"
+	name := "tf-acc-test-channel"
 	state_stopped := "STOPPED"
 	state_running := "RUNNING"
 	mw_s := "30"
@@ -39,22 +65,24 @@ func TestAccChannelResourceBasic(t *testing.T) {
 			{
 				Config: basicChannel(name, state_stopped, mw_s, mbt_s, mup_s, spd_s, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_channel.test", "id", "test"),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "id", "tf-acc-test-channel"),
 					resource.TestMatchResourceAttr("awsmt_channel.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:channel\/.*$`)),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "test"),
-					resource.TestMatchResourceAttr("awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestMatchResourceAttr("awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "tf-acc-test-channel"),
+					resource.TestMatchResourceAttr("awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestMatchResourceAttr("awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
 					resource.TestCheckResourceAttr("awsmt_channel.test", "channel_state", "STOPPED"),
 					resource.TestCheckResourceAttr("awsmt_channel.test", "playback_mode", "LOOP"),
 					resource.TestMatchResourceAttr("awsmt_channel.test", "policy", regexp.MustCompile(`mediatailor:GetManifest`)),
 					resource.TestCheckResourceAttr("awsmt_channel.test", "tier", "BASIC"),
 					resource.TestCheckResourceAttr("awsmt_channel.test", "tags.Environment", "dev"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.manifest_name", "default"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.source_group", "default"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.dash_playlist_settings.manifest_window_seconds", "30"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.dash_playlist_settings.min_buffer_time_seconds", "2"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.dash_playlist_settings.min_update_period_seconds", "2"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.dash_playlist_settings.suggested_presentation_delay_seconds", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_channel.test", "outputs.*", map[string]string{
+						"manifest_name": "default",
+						"source_group":  "default",
+						"dash_playlist_settings.manifest_window_seconds":              "30",
+						"dash_playlist_settings.min_buffer_time_seconds":              "2",
+						"dash_playlist_settings.min_update_period_seconds":            "2",
+						"dash_playlist_settings.suggested_presentation_delay_seconds": "2",
+					}),
 				),
 			},
 			// ImportState testing
@@ -62,26 +90,33 @@ func TestAccChannelResourceBasic(t *testing.T) {
 				ResourceName: "awsmt_channel.test",
 				ImportState:  true,
 			},
+			{
+				ResourceName:      "awsmt_channel.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFromArn("awsmt_channel.test", "arn"),
+			},
 			// Update and Read testing
 			{
 				Config: basicChannel(name, state_running, mw_s2, mbt_s2, mup_s2, spd_s2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_channel.test", "id", "test"),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "id", "tf-acc-test-channel"),
 					resource.TestMatchResourceAttr("awsmt_channel.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:channel\/.*$`)),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "test"),
-					resource.TestMatchResourceAttr("awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestMatchResourceAttr("awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "tf-acc-test-channel"),
+					resource.TestMatchResourceAttr("awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestMatchResourceAttr("awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
 					resource.TestCheckResourceAttr("awsmt_channel.test", "channel_state", "RUNNING"),
 					resource.TestCheckResourceAttr("awsmt_channel.test", "playback_mode", "LOOP"),
 					resource.TestCheckResourceAttr("awsmt_channel.test", "tier", "BASIC"),
 					resource.TestCheckResourceAttr("awsmt_channel.test", "tags.Environment", "prod"),
 					resource.TestCheckResourceAttr("awsmt_channel.test", "tags.Testing", "pass"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.manifest_name", "default"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.source_group", "default"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.dash_playlist_settings.manifest_window_seconds", "40"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.dash_playlist_settings.min_buffer_time_seconds", "3"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.dash_playlist_settings.min_update_period_seconds", "3"),
-					resource.TestCheckResourceAttr("awsmt_channel.test", "outputs.0.dash_playlist_settings.suggested_presentation_delay_seconds", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_channel.test", "outputs.*", map[string]string{
+						"manifest_name": "default",
+						"source_group":  "default",
+						"dash_playlist_settings.manifest_window_seconds":              "40",
+						"dash_playlist_settings.min_buffer_time_seconds":              "3",
+						"dash_playlist_settings.min_update_period_seconds":            "3",
+						"dash_playlist_settings.suggested_presentation_delay_seconds": "3",
+					}),
 				),
 			},
 		},
@@ -104,7 +139,7 @@ func TestAccChannelResourceErrors(t *testing.T) {
 func TestAccChannelResourceNoState(t *testing.T) {
 	noStateChannel := `
 resource "awsmt_channel" "test"  {
-	name = "test"
+	name = "tf-acc-test-channel"
 	outputs = [{
 		manifest_name                = "default"
 		source_group                 = "default"
@@ -126,7 +161,7 @@ resource "awsmt_channel" "test"  {
 			{
 				Config: noStateChannel,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "test"),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "tf-acc-test-channel"),
 				),
 			},
 		},
@@ -158,6 +193,50 @@ func TestAccChannelResourceRunning(t *testing.T) {
 	})
 }
 
+func TestAccChannelResourceStateDrift(t *testing.T) {
+	stopChannel := func() {
+		client, err := sweeperClient(os.Getenv("AWS_REGION"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.StopChannel(&mediatailor.StopChannelInput{ChannelName: aws.String("tf-acc-test-channel")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: driftChannel("true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_channel.test", "channel_state", "RUNNING"),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "enforce_channel_state", "true"),
+				),
+			},
+			{
+				PreConfig:          stopChannel,
+				Config:             driftChannel("true"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: driftChannel("false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_channel.test", "channel_state", "STOPPED"),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "enforce_channel_state", "false"),
+				),
+			},
+			{
+				Config: driftChannel("true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_channel.test", "channel_state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccChannelResourceSTANDARD(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -173,6 +252,34 @@ func TestAccChannelResourceSTANDARD(t *testing.T) {
 	})
 }
 
+func TestAccChannelResourceDefaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: defaultsChannel(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_channel.test", "tier", "BASIC"),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_channel.test", "outputs.*", map[string]string{
+						"manifest_name":                                 "hls",
+						"hls_playlist_settings.ad_markup_type.#":        "1",
+						"hls_playlist_settings.ad_markup_type.0":        "DATERANGE",
+						"hls_playlist_settings.manifest_window_seconds": "60",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_channel.test", "outputs.*", map[string]string{
+						"manifest_name": "dash",
+						"dash_playlist_settings.manifest_window_seconds":              "60",
+						"dash_playlist_settings.min_buffer_time_seconds":              "30",
+						"dash_playlist_settings.min_update_period_seconds":            "2",
+						"dash_playlist_settings.suggested_presentation_delay_seconds": "25",
+					}),
+				),
+			},
+		},
+	})
+}
+
 func basicChannel(name, state, mw_s, mbt_s, mup_s, spd_s, k1, v1, k2, v2 string) string {
 	return fmt.Sprintf(
 		`
@@ -211,7 +318,7 @@ func basicChannel(name, state, mw_s, mbt_s, mup_s, spd_s, k1, v1, k2, v2 string)
 func errorChannel() string {
 	return `
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-test-channel"
   					channel_state = "RUNNING"
   					outputs = [{
     					manifest_name                = "default"
@@ -239,7 +346,7 @@ func errorChannel() string {
 func hlsChannel(mw_s string) string {
 	return fmt.Sprintf(`
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-test-channel"
   					channel_state = "RUNNING"
   					outputs = [{
     					manifest_name                = "default"
@@ -269,20 +376,20 @@ path = "/"
 source_group = "default"
 type = "HLS"
 }]
-source_location_name = awsmt_source_location.test_source_location.name
-name = "vod_source_example"
+source_location_name = awsmt_source_location.tf-acc-test-source-location.name
+name = "tf-acc-test-vod-source"
 tags = {"Environment": "dev"}
 }
 data "awsmt_vod_source" "data_test" {
-source_location_name = awsmt_source_location.test_source_location.name
+source_location_name = awsmt_source_location.tf-acc-test-source-location.name
 name = awsmt_vod_source.test.name
 }
 
 output "vod_source_out" {
 value = data.awsmt_vod_source.data_test
 }
-resource "awsmt_source_location" "test_source_location"{
-name = "test_source_location"
+resource "awsmt_source_location" "tf-acc-test-source-location"{
+name = "tf-acc-test-source-location"
 http_configuration = {
 base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
 }
@@ -291,13 +398,13 @@ base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/test-img.
 }
 }
 data "awsmt_source_location" "test" {
-name = awsmt_source_location.test_source_location.name
+name = awsmt_source_location.tf-acc-test-source-location.name
 }
 output "awsmt_source_location" {
 value = data.awsmt_source_location.test
 }
 resource "awsmt_channel" "test"  {
-name = "test"
+name = "tf-acc-test-channel"
 channel_state = "STOPPED"
 outputs = [{
 manifest_name                = "default"
@@ -309,7 +416,7 @@ manifest_window_seconds = 30
 }]
 playback_mode = "LINEAR"
 filler_slate = {
-source_location_name = awsmt_source_location.test_source_location.name
+source_location_name = awsmt_source_location.tf-acc-test-source-location.name
 vod_source_name = awsmt_vod_source.test.name
 }
 policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/test\"}]}"
@@ -325,3 +432,41 @@ value = data.awsmt_channel.test
 }
 `
 }
+
+func driftChannel(enforce string) string {
+	return fmt.Sprintf(`
+resource "awsmt_channel" "test"  {
+	name = "tf-acc-test-channel"
+	channel_state = "RUNNING"
+	enforce_channel_state = %[1]s
+	outputs = [{
+		manifest_name                = "default"
+		source_group                 = "default"
+		hls_playlist_settings = {
+			ad_markup_type = ["DATERANGE"]
+			manifest_window_seconds = "30"
+		}
+	}]
+	playback_mode = "LOOP"
+	tier = "BASIC"
+}
+`, enforce)
+}
+
+func defaultsChannel() string {
+	return `
+				resource "awsmt_channel" "test"  {
+  					name = "tf-acc-test-channel"
+  					outputs = [{
+    					manifest_name         = "hls"
+						source_group          = "default"
+    					hls_playlist_settings = {}
+  					}, {
+    					manifest_name          = "dash"
+						source_group           = "default"
+    					dash_playlist_settings = {}
+  					}]
+  					playback_mode = "LOOP"
+				}
+				`
+}
@@ -2,12 +2,11 @@ package awsmt
 
 import (
 	"context"
-	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"reflect"
-	"strings"
 
 	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -15,9 +14,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &resourceLiveSource{}
-	_ resource.ResourceWithConfigure   = &resourceLiveSource{}
-	_ resource.ResourceWithImportState = &resourceLiveSource{}
+	_ resource.Resource                 = &resourceLiveSource{}
+	_ resource.ResourceWithConfigure    = &resourceLiveSource{}
+	_ resource.ResourceWithImportState  = &resourceLiveSource{}
+	_ resource.ResourceWithUpgradeState = &resourceLiveSource{}
 )
 
 func ResourceLiveSource() resource.Resource {
@@ -25,20 +25,21 @@ func ResourceLiveSource() resource.Resource {
 }
 
 type resourceLiveSource struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
 }
 
 func (r *resourceLiveSource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_live_source"
 }
 
-func (r *resourceLiveSource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *resourceLiveSource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id":            computedString,
-			"arn":           computedString,
-			"creation_time": computedString,
-			"http_package_configurations": schema.ListNestedAttribute{
+			"id":            computedStringKeptUnless(path.MatchRoot("name"), path.MatchRoot("source_location_name")),
+			"arn":           computedStringKeptUnless(path.MatchRoot("name"), path.MatchRoot("source_location_name")),
+			"creation_time": computedTimestampKeptUnless(path.MatchRoot("name"), path.MatchRoot("source_location_name")),
+			"http_package_configurations": schema.SetNestedAttribute{
 				Required: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -52,10 +53,12 @@ func (r *resourceLiveSource) Schema(_ context.Context, _ resource.SchemaRequest,
 						},
 					},
 				},
+				Validators: []validator.Set{uniqueKeys("HTTP Package Configuration", "source_group", "type")},
 			},
-			"last_modified_time":   computedString,
+			"last_modified_time":   computedTimestamp,
 			"source_location_name": requiredString,
 			"tags":                 optionalMap,
+			"timeouts":             timeouts.Attributes(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 			"name":                 requiredString,
 		},
 	}
@@ -66,7 +69,7 @@ func (r *resourceLiveSource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.MediaTailor)
+	r.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (r *resourceLiveSource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -78,11 +81,21 @@ func (r *resourceLiveSource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_live_source", "create", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create, defaultCreateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	input := liveSourceInput(plan)
 
-	liveSource, err := r.client.CreateLiveSource(&input)
+	liveSource, err := r.client.CreateLiveSourceWithContext(ctx, &input)
 	if err != nil {
-		resp.Diagnostics.AddError("Error while creating live source", err.Error())
+		addAPIError(&resp.Diagnostics, "Error while creating live source "+*input.LiveSourceName, err, sourceErrorFields)
 		return
 	}
 
@@ -103,6 +116,16 @@ func (r *resourceLiveSource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_live_source", "read", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var sourceLocationName, name string
 
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source_location_name"), &sourceLocationName)...)
@@ -116,9 +139,14 @@ func (r *resourceLiveSource) Read(ctx context.Context, req resource.ReadRequest,
 	input.LiveSourceName = &name
 	input.SourceLocationName = &sourceLocationName
 
-	liveSource, err := r.client.DescribeLiveSource(input)
+	liveSource, err := r.client.DescribeLiveSourceWithContext(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing live source", err.Error())
+		if isNotFound(err) {
+			// The resource was deleted outside of Terraform, which plans to create it again.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error while describing live source "+name, err, sourceErrorFields)
 		return
 	}
 
@@ -139,13 +167,23 @@ func (r *resourceLiveSource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_live_source", "update", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update, defaultUpdateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	input := &mediatailor.DescribeLiveSourceInput{}
 	input.LiveSourceName = plan.Name
 	input.SourceLocationName = plan.SourceLocationName
 
-	liveSource, err := r.client.DescribeLiveSource(input)
+	liveSource, err := r.client.DescribeLiveSourceWithContext(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing live source", err.Error())
+		addAPIError(&resp.Diagnostics, "Error while describing live source "+*plan.Name, err, sourceErrorFields)
 		return
 	}
 
@@ -154,22 +192,18 @@ func (r *resourceLiveSource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Check if tags are different
 	if !reflect.DeepEqual(oldTags, newTags) {
-		err = updatesTags(r.client, oldTags, newTags, *liveSource.Arn)
+		err = updatesTags(ctx, r.client, oldTags, newTags, *liveSource.Arn)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error while updating live source tags"+err.Error(),
-				err.Error(),
-			)
+			addAPIError(&resp.Diagnostics, "Error while updating the tags of live source "+*plan.Name, err, sourceErrorFields)
+			return
 		}
 	}
 
 	updateInput := liveSourceUpdateInput(plan)
-	updatedLiveSource, err := r.client.UpdateLiveSource(&updateInput)
+	updatedLiveSource, err := r.client.UpdateLiveSourceWithContext(ctx, &updateInput)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while updating live source "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while updating live source "+*plan.Name, err, sourceErrorFields)
+		return
 	}
 
 	plan = readLiveSourceToPlan(plan, mediatailor.CreateLiveSourceOutput(*updatedLiveSource))
@@ -189,27 +223,30 @@ func (r *resourceLiveSource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_live_source", "delete", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete, defaultDeleteTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	params := &mediatailor.DeleteLiveSourceInput{}
 	params.LiveSourceName = state.Name
 	params.SourceLocationName = state.SourceLocationName
 
-	_, err := r.client.DeleteLiveSource(params)
+	_, err := r.client.DeleteLiveSourceWithContext(ctx, params)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while deleting live source "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while deleting live source "+*state.Name, err, sourceErrorFields)
 	}
 }
 
 func (r *resourceLiveSource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, ",")
-
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: source_location_name, name. Got: %q", req.ID),
-		)
+	idParts, err := importNamesFromID(ctx, r.client, req.ID, "liveSource")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
 		return
 	}
 
@@ -217,3 +254,12 @@ func (r *resourceLiveSource) ImportState(ctx context.Context, req resource.Impor
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), idParts[1])...)
 
 }
+
+func (r *resourceLiveSource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeStateJSON(upgradeLiveSourceStateV0)},
+	}
+}
+
+// upgradeLiveSourceStateV0 converts the timestamps to RFC 3339, which version 1 stores them as.
+var upgradeLiveSourceStateV0 = upgradeTimestamps("creation_time", "last_modified_time")
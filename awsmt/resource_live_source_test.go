@@ -8,7 +8,7 @@ import (
 )
 
 func TestAccLiveSourceResourceBasic(t *testing.T) {
-	name := "live_source_example"
+	name := "tf-acc-test-live-source"
 	path := "/"
 	path2 := "/test"
 	k1 := "Environment"
@@ -24,15 +24,17 @@ func TestAccLiveSourceResourceBasic(t *testing.T) {
 			{
 				Config: basicLiveSourceWithSourceLocation(name, path, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "id", "test_source_location,live_source_example"),
+					resource.TestCheckResourceAttr("awsmt_live_source.test", "id", "tf-acc-test-source-location,tf-acc-test-live-source"),
 					resource.TestMatchResourceAttr("awsmt_live_source.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:liveSource\/.*$`)),
-					resource.TestMatchResourceAttr("awsmt_live_source.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "http_package_configurations.0.path", "/"),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "http_package_configurations.0.source_group", "default"),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr("awsmt_live_source.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "name", "live_source_example"),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr("awsmt_live_source.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_live_source.test", "http_package_configurations.*", map[string]string{
+						"path":         "/",
+						"source_group": "default",
+						"type":         "HLS",
+					}),
+					resource.TestMatchResourceAttr("awsmt_live_source.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckResourceAttr("awsmt_live_source.test", "name", "tf-acc-test-live-source"),
+					resource.TestCheckResourceAttr("awsmt_live_source.test", "source_location_name", "tf-acc-test-source-location"),
 					resource.TestCheckResourceAttr("awsmt_live_source.test", "tags.Environment", "dev"),
 					resource.TestCheckResourceAttr("awsmt_live_source.test", "tags.Testing", "pass"),
 				),
@@ -40,15 +42,17 @@ func TestAccLiveSourceResourceBasic(t *testing.T) {
 			{
 				Config: basicLiveSourceWithSourceLocation(name, path2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "id", "test_source_location,live_source_example"),
+					resource.TestCheckResourceAttr("awsmt_live_source.test", "id", "tf-acc-test-source-location,tf-acc-test-live-source"),
 					resource.TestMatchResourceAttr("awsmt_live_source.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:liveSource\/.*$`)),
-					resource.TestMatchResourceAttr("awsmt_live_source.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "http_package_configurations.0.path", "/test"),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "http_package_configurations.0.source_group", "default"),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr("awsmt_live_source.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "name", "live_source_example"),
-					resource.TestCheckResourceAttr("awsmt_live_source.test", "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr("awsmt_live_source.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_live_source.test", "http_package_configurations.*", map[string]string{
+						"path":         "/test",
+						"source_group": "default",
+						"type":         "HLS",
+					}),
+					resource.TestMatchResourceAttr("awsmt_live_source.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckResourceAttr("awsmt_live_source.test", "name", "tf-acc-test-live-source"),
+					resource.TestCheckResourceAttr("awsmt_live_source.test", "source_location_name", "tf-acc-test-source-location"),
 					resource.TestCheckResourceAttr("awsmt_live_source.test", "tags.Environment", "prod"),
 					resource.TestCheckResourceAttr("awsmt_live_source.test", "tags.Testing", "pass"),
 				),
@@ -64,7 +68,7 @@ func basicLiveSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string
 								source_group = "default"
     							type = "HLS"
   							}]
-  							source_location_name = awsmt_source_location.test_source_location.name
+  							source_location_name = awsmt_source_location.tf-acc-test-source-location.name
   							name = "%[1]s"
 							tags = {
    		 						"%[3]s": "%[4]s",
@@ -72,15 +76,15 @@ func basicLiveSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string
 							}
 						}
 						data "awsmt_live_source" "data_test" {
-  							source_location_name = awsmt_source_location.test_source_location.name
+  							source_location_name = awsmt_source_location.tf-acc-test-source-location.name
   							name = awsmt_live_source.test.name
 						}
 
 						output "live_source_out" {
   							value = data.awsmt_live_source.data_test
 						}
-						resource "awsmt_source_location" "test_source_location"{
-  							name = "test_source_location"
+						resource "awsmt_source_location" "tf-acc-test-source-location"{
+  							name = "tf-acc-test-source-location"
   							http_configuration = {
     							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   							}
@@ -89,7 +93,7 @@ func basicLiveSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string
   							}
 						}
 						data "awsmt_source_location" "test" {
-  							name = awsmt_source_location.test_source_location.name
+  							name = awsmt_source_location.tf-acc-test-source-location.name
 						}
 						output "awsmt_source_location" {
   							value = data.awsmt_source_location.test
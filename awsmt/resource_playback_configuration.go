@@ -3,17 +3,26 @@ package awsmt
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"reflect"
+	"regexp"
 )
 
 var (
-	_ resource.Resource                = &resourcePlaybackConfiguration{}
-	_ resource.ResourceWithConfigure   = &resourcePlaybackConfiguration{}
-	_ resource.ResourceWithImportState = &resourcePlaybackConfiguration{}
+	_ resource.Resource                   = &resourcePlaybackConfiguration{}
+	_ resource.ResourceWithConfigure      = &resourcePlaybackConfiguration{}
+	_ resource.ResourceWithImportState    = &resourcePlaybackConfiguration{}
+	_ resource.ResourceWithValidateConfig = &resourcePlaybackConfiguration{}
+	_ resource.ResourceWithUpgradeState   = &resourcePlaybackConfiguration{}
 )
 
 func ResourcePlaybackConfiguration() resource.Resource {
@@ -21,38 +30,78 @@ func ResourcePlaybackConfiguration() resource.Resource {
 }
 
 type resourcePlaybackConfiguration struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
+}
+
+// playbackConfigurationErrorFields maps the fields named in MediaTailor errors to the playback configuration attributes.
+var playbackConfigurationErrorFields = apiErrorFields{
+	"Name":                            path.Root("name"),
+	"AdDecisionServerUrl":             path.Root("ad_decision_server_url"),
+	"AvailSuppression":                path.Root("avail_supression"),
+	"Bumper":                          path.Root("bumper"),
+	"CdnConfiguration":                path.Root("cdn_configuration"),
+	"ConfigurationAliases":            path.Root("configuration_aliases"),
+	"DashConfiguration":               path.Root("dash_configuration"),
+	"OriginManifestType":              path.Root("dash_configuration").AtName("origin_manifest_type"),
+	"HlsConfiguration":                path.Root("hls_configuration"),
+	"LivePreRollConfiguration":        path.Root("live_pre_roll_configuration"),
+	"LogConfiguration":                path.Root("log_configuration"),
+	"ManifestProcessingRules":         path.Root("manifest_processing_rules"),
+	"PersonalizationThresholdSeconds": path.Root("personalization_threshold_seconds"),
+	"SlateAdUrl":                      path.Root("slate_ad_url"),
+	"TranscodeProfileName":            path.Root("transcode_profile_name"),
+	"VideoContentSourceUrl":           path.Root("video_content_source_url"),
+	"Tag":                             path.Root("tags"),
 }
 
 func (r *resourcePlaybackConfiguration) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_playback_configuration"
 }
 
-func (r *resourcePlaybackConfiguration) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *resourcePlaybackConfiguration) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id":                     computedString,
-			"ad_decision_server_url": requiredString,
+			"id": computedStringKeptUnless(path.MatchRoot("name")),
+			"ad_decision_server_url": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{httpUrl(), adsUrlTemplate()},
+			},
 			"avail_supression": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
-					"fill_policy": optionalString,
-					"mode":        optionalString,
-					"value":       optionalString,
+					"fill_policy": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("FULL_AVAIL_ONLY", "PARTIAL_AVAIL"),
+						},
+					},
+					"mode": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("OFF", "BEHIND_LIVE_EDGE", "AFTER_LIVE_EDGE"),
+						},
+					},
+					"value": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d:[0-5]\d$`), "must be a time in the format HH:MM:SS"),
+						},
+					},
 				},
 			},
 			"bumper": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
-					"end_url":   optionalString,
-					"start_url": optionalString,
+					"end_url":   optionalHttpUrl,
+					"start_url": optionalHttpUrl,
 				},
 			},
 			"cdn_configuration": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
-					"ad_segment_url_prefix":      optionalString,
-					"content_segment_url_prefix": optionalString,
+					"ad_segment_url_prefix":      optionalHttpUrl,
+					"content_segment_url_prefix": optionalHttpUrl,
 				},
 			},
 			"configuration_aliases": schema.MapAttribute{
@@ -60,22 +109,68 @@ func (r *resourcePlaybackConfiguration) Schema(_ context.Context, _ resource.Sch
 				ElementType: types.MapType{
 					ElemType: types.StringType,
 				},
+				Validators: []validator.Map{configurationAliases()},
 			},
 			"dash_configuration": schema.SingleNestedAttribute{
 				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"manifest_endpoint_prefix": computedStringKeptUnless(path.MatchRoot("name")),
+					"mpd_location": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString("EMT_DEFAULT"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("DISABLED", "EMT_DEFAULT"),
+						},
+					},
+					"origin_manifest_type": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString("MULTI_PERIOD"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("SINGLE_PERIOD", "MULTI_PERIOD"),
+						},
+					},
+				},
+			},
+			"hls_configuration": schema.SingleNestedAttribute{
+				Computed: true,
 				Attributes: map[string]schema.Attribute{
 					"manifest_endpoint_prefix": computedString,
-					"mpd_location":             optionalString,
-					"origin_manifest_type":     optionalString,
 				},
+				PlanModifiers: []planmodifier.Object{useStateForUnknownUnlessChanged(path.MatchRoot("name"))},
+			},
+			"hls_configuration_manifest_endpoint_prefix": schema.StringAttribute{
+				Computed:           true,
+				PlanModifiers:      []planmodifier.String{useStateForUnknownUnlessChanged(path.MatchRoot("name"))},
+				DeprecationMessage: "Use hls_configuration.manifest_endpoint_prefix instead. This attribute will be removed in the next major version.",
+			},
+			"log_configuration": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"percent_enabled": computedInt64,
+				},
+				PlanModifiers: []planmodifier.Object{useStateForUnknownUnlessChanged(path.MatchRoot("name"))},
+			},
+			"log_configuration_percent_enabled": schema.Int64Attribute{
+				Computed:           true,
+				PlanModifiers:      []planmodifier.Int64{useStateForUnknownUnlessChanged(path.MatchRoot("name"))},
+				DeprecationMessage: "Use log_configuration.percent_enabled instead. This attribute will be removed in the next major version.",
 			},
-			"hls_configuration_manifest_endpoint_prefix": computedString,
-			"log_configuration_percent_enabled":          computedInt64,
 			"live_pre_roll_configuration": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
-					"ad_decision_server_url": optionalString,
-					"max_duration_seconds":   optionalInt64,
+					"ad_decision_server_url": schema.StringAttribute{
+						Optional:   true,
+						Validators: []validator.String{httpUrl(), adsUrlTemplate()},
+					},
+					"max_duration_seconds": schema.Int64Attribute{
+						Optional: true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("ad_decision_server_url")),
+						},
+					},
 				},
 			},
 			"manifest_processing_rules": schema.SingleNestedAttribute{
@@ -89,25 +184,122 @@ func (r *resourcePlaybackConfiguration) Schema(_ context.Context, _ resource.Sch
 					},
 				},
 			},
-			"name":                                   requiredString,
-			"personalization_threshold_seconds":      optionalInt64,
-			"playback_configuration_arn":             computedString,
-			"playback_endpoint_prefix":               computedString,
-			"session_initialization_endpoint_prefix": computedString,
-			"slate_ad_url":                           optionalString,
+			"name": requiredString,
+			"personalization_threshold_seconds": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"playback_configuration_arn":             computedStringKeptUnless(path.MatchRoot("name")),
+			"playback_endpoint_prefix":               computedStringKeptUnless(path.MatchRoot("name")),
+			"session_initialization_endpoint_prefix": computedStringKeptUnless(path.MatchRoot("name")),
+			"slate_ad_url":                           optionalHttpUrl,
 			"tags":                                   optionalMap,
+			"timeouts":                               timeouts.Attributes(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 			"transcode_profile_name":                 optionalString,
-			"video_content_source_url":               requiredString,
+			"video_content_source_url": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{httpUrl()},
+			},
 		},
 	}
 }
 
+// playbackConfigurationUrlPaths are the URL attributes in which MediaTailor resolves player_params, directly or
+// through configuration aliases.
+var playbackConfigurationUrlPaths = []path.Path{
+	path.Root("ad_decision_server_url"),
+	path.Root("cdn_configuration").AtName("ad_segment_url_prefix"),
+	path.Root("cdn_configuration").AtName("content_segment_url_prefix"),
+	path.Root("live_pre_roll_configuration").AtName("ad_decision_server_url"),
+	path.Root("video_content_source_url"),
+}
+
+// ValidateConfig checks the combinations of attributes PutPlaybackConfiguration rejects: avail suppression settings
+// that do not fit the mode, player_params used in the domain of a URL without configuration aliases, since
+// MediaTailor cannot resolve them otherwise, and configuration aliases not used in any of the URLs.
+func (r *resourcePlaybackConfiguration) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateAvailSuppression(ctx, req, resp)
+
+	var aliases types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("configuration_aliases"), &aliases)...)
+	if resp.Diagnostics.HasError() || aliases.IsUnknown() {
+		return
+	}
+
+	// Aliases are only reported as unused if every URL is known and could be parsed, as the others might use them.
+	used := map[string]bool{}
+	complete := true
+	for _, p := range playbackConfigurationUrlPaths {
+		var url types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &url)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if url.IsUnknown() {
+			complete = false
+			continue
+		}
+		if url.IsNull() {
+			continue
+		}
+
+		// Syntax errors of ad decision server URLs are reported by the attribute validator.
+		variables, err := parseAdsUrlTemplate(url.ValueString())
+		if err != nil {
+			complete = false
+			continue
+		}
+		for _, v := range variables {
+			used[v.String()] = true
+		}
+		for _, v := range adsUrlHostVariables(url.ValueString(), variables) {
+			if _, ok := aliases.Elements()[v.String()]; !ok {
+				resp.Diagnostics.AddAttributeError(p, "Missing Configuration Alias",
+					"["+v.String()+"] is used in the domain of the URL, so configuration_aliases must contain \""+v.String()+"\".")
+			}
+		}
+	}
+
+	if !complete {
+		return
+	}
+	for key := range aliases.Elements() {
+		if !used[key] {
+			resp.Diagnostics.AddAttributeError(path.Root("configuration_aliases").AtMapKey(key), "Unused Configuration Alias",
+				"\""+key+"\" is not used as ["+key+"] in the ad decision server, video content source or CDN URLs.")
+		}
+	}
+}
+
+func validateAvailSuppression(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var mode, value, fillPolicy types.String
+	root := path.Root("avail_supression")
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, root.AtName("mode"), &mode)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, root.AtName("value"), &value)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, root.AtName("fill_policy"), &fillPolicy)...)
+	if resp.Diagnostics.HasError() || mode.IsUnknown() {
+		return
+	}
+
+	suppressing := mode.ValueString() == "BEHIND_LIVE_EDGE" || mode.ValueString() == "AFTER_LIVE_EDGE"
+	if suppressing && value.IsNull() {
+		resp.Diagnostics.AddAttributeError(root.AtName("value"), "Missing Avail Suppression Value",
+			"value is required when mode is "+mode.ValueString()+".")
+	}
+	if fillPolicy.ValueString() == "PARTIAL_AVAIL" && mode.ValueString() != "AFTER_LIVE_EDGE" {
+		resp.Diagnostics.AddAttributeError(root.AtName("fill_policy"), "Invalid Avail Suppression Fill Policy",
+			"PARTIAL_AVAIL can only be used with the mode AFTER_LIVE_EDGE.")
+	}
+}
+
 func (r *resourcePlaybackConfiguration) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.MediaTailor)
+	r.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (r *resourcePlaybackConfiguration) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -119,14 +311,21 @@ func (r *resourcePlaybackConfiguration) Create(ctx context.Context, req resource
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_playback_configuration", "create", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create, defaultCreateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	input := playbackConfigurationInput(plan)
 
-	playbackConfiguration, err := r.client.PutPlaybackConfiguration(&input)
+	playbackConfiguration, err := r.client.PutPlaybackConfigurationWithContext(ctx, &input)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while creating playback configuration "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while creating playback configuration "+*input.Name, err, playbackConfigurationErrorFields)
 		return
 	}
 
@@ -147,15 +346,27 @@ func (r *resourcePlaybackConfiguration) Read(ctx context.Context, req resource.R
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_playback_configuration", "read", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	name := state.Name
 
 	// Get the playback configuration
-	playbackConfiguration, err := r.client.GetPlaybackConfiguration(&mediatailor.GetPlaybackConfigurationInput{Name: name})
+	playbackConfiguration, err := r.client.GetPlaybackConfigurationWithContext(ctx, &mediatailor.GetPlaybackConfigurationInput{Name: name})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while retrieving playback configuration "+err.Error(),
-			err.Error(),
-		)
+		if isNotFound(err) {
+			// The resource was deleted outside of Terraform, which plans to create it again.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error while getting playback configuration "+*name, err, playbackConfigurationErrorFields)
 		return
 	}
 
@@ -178,16 +389,23 @@ func (r *resourcePlaybackConfiguration) Update(ctx context.Context, req resource
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_playback_configuration", "update", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update, defaultUpdateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// retrieve the resource playbackConfiguration
 	name := plan.Name
 
 	// Get the playback configuration
-	playbackConfiguration, err := r.client.GetPlaybackConfiguration(&mediatailor.GetPlaybackConfigurationInput{Name: name})
+	playbackConfiguration, err := r.client.GetPlaybackConfigurationWithContext(ctx, &mediatailor.GetPlaybackConfigurationInput{Name: name})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while retrieving playback configuration "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while getting playback configuration "+*name, err, playbackConfigurationErrorFields)
 		return
 	}
 
@@ -202,24 +420,19 @@ func (r *resourcePlaybackConfiguration) Update(ctx context.Context, req resource
 
 	// Check if tags are different
 	if !reflect.DeepEqual(oldTags, newTags) {
-		err = untagResource(r.client, oldTags, *playbackConfiguration.PlaybackConfigurationArn)
+		err = untagResource(ctx, r.client, oldTags, *playbackConfiguration.PlaybackConfigurationArn)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error while untaging playback configuration tags"+err.Error(),
-				err.Error(),
-			)
+			addAPIError(&resp.Diagnostics, "Error while removing the tags of playback configuration "+*name, err, playbackConfigurationErrorFields)
+			return
 		}
 	}
 
 	input := playbackConfigurationInput(plan)
 
 	// Update the playback configuration
-	playbackConfigurationUpdate, err := r.client.PutPlaybackConfiguration(&input)
+	playbackConfigurationUpdate, err := r.client.PutPlaybackConfigurationWithContext(ctx, &input)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while updating playback configuration "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while updating playback configuration "+*name, err, playbackConfigurationErrorFields)
 		return
 	}
 
@@ -239,18 +452,51 @@ func (r *resourcePlaybackConfiguration) Delete(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx, span := startResourceSpan(ctx, "awsmt_playback_configuration", "delete", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete, defaultDeleteTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	name := state.Name
-	_, err := r.client.DeletePlaybackConfiguration(&mediatailor.DeletePlaybackConfigurationInput{Name: name})
+	_, err := r.client.DeletePlaybackConfigurationWithContext(ctx, &mediatailor.DeletePlaybackConfigurationInput{Name: name})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while deleting playback configuration "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while deleting playback configuration "+*name, err, playbackConfigurationErrorFields)
 		return
 	}
 
 }
 
 func (r *resourcePlaybackConfiguration) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	names, err := importNamesFromID(ctx, r.client, req.ID, "playbackConfiguration")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), names[0])...)
+}
+
+func (r *resourcePlaybackConfiguration) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeStateJSON(upgradePlaybackConfigurationStateV0)},
+	}
+}
+
+// upgradePlaybackConfigurationStateV0 fills the hls_configuration and log_configuration objects added in version 1
+// from the flattened attributes of version 0.
+func upgradePlaybackConfigurationStateV0(state map[string]interface{}) error {
+	state["hls_configuration"] = nil
+	if prefix := state["hls_configuration_manifest_endpoint_prefix"]; prefix != nil {
+		state["hls_configuration"] = map[string]interface{}{"manifest_endpoint_prefix": prefix}
+	}
+	state["log_configuration"] = nil
+	if percent := state["log_configuration_percent_enabled"]; percent != nil {
+		state["log_configuration"] = map[string]interface{}{"percent_enabled": percent}
+	}
+	return nil
 }
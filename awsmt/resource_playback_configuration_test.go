@@ -3,11 +3,12 @@ package awsmt
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
 	"testing"
 )
 
 func TestAccPlaybackConfigurationResource(t *testing.T) {
-	name := "example-playback-configuration-awsmt"
+	name := "tf-acc-test-playback-configuration"
 	ad_url := "https://exampleurl.com/"
 	ad_url2 := "https://exampleurl2.com/"
 	bumper_e := "https://wxample.com/endbumper"
@@ -34,7 +35,7 @@ func TestAccPlaybackConfigurationResource(t *testing.T) {
 			{
 				Config: basicPlaybackConfiguration(name, ad_url, bumper_e, bumper_s, cdn_url, max_d, p_s, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "id", "example-playback-configuration-awsmt"),
+					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "id", "tf-acc-test-playback-configuration"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "ad_decision_server_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "avail_supression.fill_policy", "FULL_AVAIL_ONLY"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "avail_supression.mode", "BEHIND_LIVE_EDGE"),
@@ -47,7 +48,7 @@ func TestAccPlaybackConfigurationResource(t *testing.T) {
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "live_pre_roll_configuration.ad_decision_server_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "live_pre_roll_configuration.max_duration_seconds", "2"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "manifest_processing_rules.ad_marker_passthrough.enabled", "false"),
-					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "name", "example-playback-configuration-awsmt"),
+					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "name", "tf-acc-test-playback-configuration"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "personalization_threshold_seconds", "2"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "slate_ad_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "tags.Environment", "dev"),
@@ -60,13 +61,20 @@ func TestAccPlaybackConfigurationResource(t *testing.T) {
 				ResourceName: "awsmt_playback_configuration.r1",
 				ImportState:  true,
 			},
+			{
+				ResourceName:      "awsmt_playback_configuration.r1",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFromArn("awsmt_playback_configuration.r1", "playback_configuration_arn"),
+			},
 			// Update and Read testing
 			{
 				Config: basicPlaybackConfiguration(name, ad_url2, bumper_e2, bumper_s2, cdn_url2, max_d2, p_s2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "name", "example-playback-configuration-awsmt"),
+					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "name", "tf-acc-test-playback-configuration"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "personalization_threshold_seconds", "3"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "log_configuration_percent_enabled", "0"),
+					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "log_configuration.percent_enabled", "0"),
+					resource.TestCheckResourceAttrPair("awsmt_playback_configuration.r1", "hls_configuration.manifest_endpoint_prefix", "awsmt_playback_configuration.r1", "hls_configuration_manifest_endpoint_prefix"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "ad_decision_server_url", "https://exampleurl2.com/"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "bumper.end_url", "https://wxample.com/endbumper2"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "bumper.start_url", "https://wxample.com/startbumper2"),
@@ -80,6 +88,79 @@ func TestAccPlaybackConfigurationResource(t *testing.T) {
 	})
 }
 
+func TestPlaybackConfigurationResourceAdsUrlValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(newFakeMediaTailor("eu-central-1", "123456789012")),
+		Steps: []resource.TestStep{
+			{
+				Config:      basicPlaybackConfiguration("tf-acc-test-playback-configuration", "https://exampleurl.com/?id=[sesion.id]", "https://wxample.com/endbumper", "https://wxample.com/startbumper", "https://exampleurl.com/", "2", "2", "Environment", "dev", "Testing", "pass"),
+				ExpectError: regexp.MustCompile("unknown namespace \"sesion\""),
+			},
+			{
+				Config:      basicPlaybackConfiguration("tf-acc-test-playback-configuration", "https://[player_params.domain].example.com/", "https://wxample.com/endbumper", "https://wxample.com/startbumper", "https://exampleurl.com/", "2", "2", "Environment", "dev", "Testing", "pass"),
+				ExpectError: regexp.MustCompile("Missing Configuration Alias"),
+			},
+		},
+	})
+}
+
+func TestAccPlaybackConfigurationResourceConfigurationAliases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      aliasesPlaybackConfiguration("origin_domain"),
+				ExpectError: regexp.MustCompile("Invalid Configuration Alias Key"),
+			},
+			{
+				Config:      aliasesPlaybackConfiguration("player_params.unused"),
+				ExpectError: regexp.MustCompile("Unused Configuration Alias"),
+			},
+			{
+				Config: aliasesPlaybackConfiguration("player_params.origin_domain"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "configuration_aliases.player_params.origin_domain.pdx", "exampleurl.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestPlaybackConfigurationResourceValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(newFakeMediaTailor("eu-central-1", "123456789012")),
+		Steps: []resource.TestStep{
+			{
+				Config:      availSuppressionPlaybackConfiguration(`mode = "BEHIND_LIVE"`),
+				ExpectError: regexp.MustCompile("value must be one of"),
+			},
+			{
+				Config:      availSuppressionPlaybackConfiguration(`mode = "BEHIND_LIVE_EDGE"` + "\n" + `value = "1:00"`),
+				ExpectError: regexp.MustCompile("must be a time in the format HH:MM:SS"),
+			},
+			{
+				Config:      availSuppressionPlaybackConfiguration(`mode = "AFTER_LIVE_EDGE"`),
+				ExpectError: regexp.MustCompile("Missing Avail Suppression Value"),
+			},
+			{
+				Config:      availSuppressionPlaybackConfiguration(`mode = "BEHIND_LIVE_EDGE"` + "\n" + `value = "00:00:00"` + "\n" + `fill_policy = "PARTIAL_AVAIL"`),
+				ExpectError: regexp.MustCompile("Invalid Avail Suppression Fill Policy"),
+			},
+			{
+				Config:      basicPlaybackConfiguration("tf-acc-test-playback-configuration", "https://exampleurl.com/", "wxample.com/endbumper", "https://wxample.com/startbumper", "https://exampleurl.com/", "2", "2", "Environment", "dev", "Testing", "pass"),
+				ExpectError: regexp.MustCompile("must be an absolute http:// or https:// URL"),
+			},
+			{
+				Config:      basicPlaybackConfiguration("tf-acc-test-playback-configuration", "https://exampleurl.com/", "https://wxample.com/endbumper", "https://wxample.com/startbumper", "https://exampleurl.com/", "2", "0", "Environment", "dev", "Testing", "pass"),
+				ExpectError: regexp.MustCompile("value must be at least 1"),
+			},
+		},
+	})
+}
+
 func basicPlaybackConfiguration(name, ad_url, bumper_e, bumper_s, cdn_url, max_d, p_s, k1, v1, k2, v2 string) string {
 	return fmt.Sprintf(`resource "awsmt_playback_configuration" "r1" {
   							ad_decision_server_url = "%[2]s"
@@ -128,3 +209,37 @@ func basicPlaybackConfiguration(name, ad_url, bumper_e, bumper_s, cdn_url, max_d
 						`, name, ad_url, bumper_e, bumper_s, cdn_url, max_d, p_s, k1, v1, k2, v2)
 
 }
+
+func aliasesPlaybackConfiguration(key string) string {
+	return fmt.Sprintf(`resource "awsmt_playback_configuration" "r1" {
+  							ad_decision_server_url = "https://exampleurl.com/?domain=[player_params.origin_domain]"
+  							configuration_aliases = {
+								"%[1]s" = {
+									"pdx" = "exampleurl.com"
+								}
+							}
+  							dash_configuration = {
+    							mpd_location = "DISABLED",
+    							origin_manifest_type = "SINGLE_PERIOD"
+  							}
+  							name = "tf-acc-test-playback-configuration"
+ 	 						video_content_source_url = "https://exampleurl.com/"
+						}
+						`, key)
+}
+
+func availSuppressionPlaybackConfiguration(availSuppression string) string {
+	return fmt.Sprintf(`resource "awsmt_playback_configuration" "r1" {
+  							ad_decision_server_url = "https://exampleurl.com/"
+  							avail_supression = {
+								%[1]s
+							}
+  							dash_configuration = {
+    							mpd_location = "DISABLED",
+    							origin_manifest_type = "SINGLE_PERIOD"
+  							}
+  							name = "tf-acc-test-playback-configuration"
+ 	 						video_content_source_url = "https://exampleurl.com/"
+						}
+						`, availSuppression)
+}
@@ -3,6 +3,7 @@ package awsmt
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -12,9 +13,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &resourceSourceLocation{}
-	_ resource.ResourceWithConfigure   = &resourceSourceLocation{}
-	_ resource.ResourceWithImportState = &resourceSourceLocation{}
+	_ resource.Resource                     = &resourceSourceLocation{}
+	_ resource.ResourceWithConfigure        = &resourceSourceLocation{}
+	_ resource.ResourceWithImportState      = &resourceSourceLocation{}
+	_ resource.ResourceWithConfigValidators = &resourceSourceLocation{}
+	_ resource.ResourceWithUpgradeState     = &resourceSourceLocation{}
 )
 
 func ResourceSourceLocation() resource.Resource {
@@ -22,17 +25,31 @@ func ResourceSourceLocation() resource.Resource {
 }
 
 type resourceSourceLocation struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
+}
+
+// sourceLocationErrorFields maps the fields named in MediaTailor errors to the source location attributes.
+var sourceLocationErrorFields = apiErrorFields{
+	"SourceLocationName":                     path.Root("name"),
+	"AccessConfiguration":                    path.Root("access_configuration"),
+	"AccessType":                             path.Root("access_configuration").AtName("access_type"),
+	"SecretsManagerAccessTokenConfiguration": path.Root("access_configuration").AtName("smatc"),
+	"DefaultSegmentDeliveryConfiguration":    path.Root("default_segment_delivery_configuration"),
+	"HttpConfiguration":                      path.Root("http_configuration"),
+	"BaseUrl":                                path.Root("http_configuration").AtName("base_url"),
+	"SegmentDeliveryConfiguration":           path.Root("segment_delivery_configurations"),
+	"Tag":                                    path.Root("tags"),
 }
 
 func (r *resourceSourceLocation) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_source_location"
 }
 
-func (r *resourceSourceLocation) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *resourceSourceLocation) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": computedString,
+			"id": computedStringKeptUnless(path.MatchRoot("name")),
 			"access_configuration": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
@@ -52,8 +69,8 @@ func (r *resourceSourceLocation) Schema(_ context.Context, _ resource.SchemaRequ
 					},
 				},
 			},
-			"arn":           computedString,
-			"creation_time": computedString,
+			"arn":           computedStringKeptUnless(path.MatchRoot("name")),
+			"creation_time": computedTimestampKeptUnless(path.MatchRoot("name")),
 			"default_segment_delivery_configuration": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
@@ -66,8 +83,8 @@ func (r *resourceSourceLocation) Schema(_ context.Context, _ resource.SchemaRequ
 					"base_url": requiredString,
 				},
 			},
-			"last_modified_time": computedString,
-			"segment_delivery_configurations": schema.ListNestedAttribute{
+			"last_modified_time": computedTimestamp,
+			"segment_delivery_configurations": schema.SetNestedAttribute{
 				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -76,18 +93,26 @@ func (r *resourceSourceLocation) Schema(_ context.Context, _ resource.SchemaRequ
 					},
 				},
 			},
-			"name": requiredString,
-			"tags": optionalMap,
+			"name":     requiredString,
+			"tags":     optionalMap,
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 		},
 	}
 }
 
+func (r *resourceSourceLocation) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		accessConfigurationValidator{},
+		segmentDeliveryConfigurationsValidator{},
+	}
+}
+
 func (r *resourceSourceLocation) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.MediaTailor)
+	r.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (r *resourceSourceLocation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,12 +124,22 @@ func (r *resourceSourceLocation) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_source_location", "create", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create, defaultCreateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	params := sourceLocationInput(plan)
 
 	// Create Source Location
-	sourceLocation, err := r.client.CreateSourceLocation(&params)
+	sourceLocation, err := r.client.CreateSourceLocationWithContext(ctx, &params)
 	if err != nil {
-		resp.Diagnostics.AddError("Error while creating source location", err.Error())
+		addAPIError(&resp.Diagnostics, "Error while creating source location "+*params.SourceLocationName, err, sourceLocationErrorFields)
 		return
 	}
 
@@ -125,11 +160,26 @@ func (r *resourceSourceLocation) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_source_location", "read", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	name := state.Name
 
-	sourceLocation, err := r.client.DescribeSourceLocation(&mediatailor.DescribeSourceLocationInput{SourceLocationName: name})
+	sourceLocation, err := r.client.DescribeSourceLocationWithContext(ctx, &mediatailor.DescribeSourceLocationInput{SourceLocationName: name})
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing source location", "Could not describe the source location: "+*name+": "+err.Error())
+		if isNotFound(err) {
+			// The resource was deleted outside of Terraform, which plans to create it again.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error while describing source location "+*name, err, sourceLocationErrorFields)
 		return
 	}
 
@@ -150,11 +200,21 @@ func (r *resourceSourceLocation) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_source_location", "update", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update, defaultUpdateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	name := plan.Name
 
-	sourceLocation, err := r.client.DescribeSourceLocation(&mediatailor.DescribeSourceLocationInput{SourceLocationName: name})
+	sourceLocation, err := r.client.DescribeSourceLocationWithContext(ctx, &mediatailor.DescribeSourceLocationInput{SourceLocationName: name})
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing source location", "Could not describe the source location: "+*name+": "+err.Error())
+		addAPIError(&resp.Diagnostics, "Error while describing source location "+*name, err, sourceLocationErrorFields)
 		return
 	}
 
@@ -163,35 +223,27 @@ func (r *resourceSourceLocation) Update(ctx context.Context, req resource.Update
 
 	// Check if tags are different
 	if !reflect.DeepEqual(oldTags, newTags) {
-		err = updatesTags(r.client, oldTags, newTags, *sourceLocation.Arn)
+		err = updatesTags(ctx, r.client, oldTags, newTags, *sourceLocation.Arn)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error while updating playback configuration tags"+err.Error(),
-				err.Error(),
-			)
+			addAPIError(&resp.Diagnostics, "Error while updating the tags of source location "+*name, err, sourceLocationErrorFields)
+			return
 		}
 	}
 
 	if !reflect.DeepEqual(sourceLocation.AccessConfiguration, plan.AccessConfiguration) {
 		// delete source location
 		name := plan.Name
-		err := deleteSourceLocation(r.client, name)
+		err := deleteSourceLocation(ctx, r.client, name)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error while deleting source location "+err.Error(),
-				err.Error(),
-			)
+			addAPIError(&resp.Diagnostics, "Error while deleting source location "+*name+" to change its access configuration", err, sourceLocationErrorFields)
 			return
 		}
 
 		// create source location
 		params := sourceLocationInput(plan)
-		sourceLocation, err := r.client.CreateSourceLocation(&params)
+		sourceLocation, err := r.client.CreateSourceLocationWithContext(ctx, &params)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error while creating new source location with new access configuration "+err.Error(),
-				err.Error(),
-			)
+			addAPIError(&resp.Diagnostics, "Error while creating source location "+*name+" with the new access configuration", err, sourceLocationErrorFields)
 			return
 		}
 
@@ -200,12 +252,9 @@ func (r *resourceSourceLocation) Update(ctx context.Context, req resource.Update
 
 	params := updateSourceLocationInput(plan)
 
-	sourceLocationUpdated, err := r.client.UpdateSourceLocation(&params)
+	sourceLocationUpdated, err := r.client.UpdateSourceLocationWithContext(ctx, &params)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating source location "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while updating source location "+*name, err, sourceLocationErrorFields)
 		return
 	}
 
@@ -226,55 +275,65 @@ func (r *resourceSourceLocation) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_source_location", "delete", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete, defaultDeleteTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	name := state.Name
 
-	vodSourcesList, err := r.client.ListVodSources(&mediatailor.ListVodSourcesInput{SourceLocationName: name})
+	vodSourcesList, err := r.client.ListVodSourcesWithContext(ctx, &mediatailor.ListVodSourcesInput{SourceLocationName: name})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error retrieving vod sources "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while listing the VOD sources of source location "+*name, err, sourceLocationErrorFields)
 		return
 	}
 	for _, vodSource := range vodSourcesList.Items {
-		_, err := r.client.DeleteVodSource(&mediatailor.DeleteVodSourceInput{SourceLocationName: name, VodSourceName: vodSource.VodSourceName})
+		_, err := r.client.DeleteVodSourceWithContext(ctx, &mediatailor.DeleteVodSourceInput{SourceLocationName: name, VodSourceName: vodSource.VodSourceName})
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error deleting vod sources "+err.Error(),
-				err.Error(),
-			)
+			addAPIError(&resp.Diagnostics, "Error while deleting VOD source "+*vodSource.VodSourceName, err, sourceLocationErrorFields)
 			return
 		}
 	}
 
-	liveSourcesList, err := r.client.ListLiveSources(&mediatailor.ListLiveSourcesInput{SourceLocationName: name})
+	liveSourcesList, err := r.client.ListLiveSourcesWithContext(ctx, &mediatailor.ListLiveSourcesInput{SourceLocationName: name})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error retrieving live sources "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while listing the live sources of source location "+*name, err, sourceLocationErrorFields)
 		return
 	}
 	for _, liveSource := range liveSourcesList.Items {
-		if _, err := r.client.DeleteLiveSource(&mediatailor.DeleteLiveSourceInput{LiveSourceName: liveSource.LiveSourceName, SourceLocationName: name}); err != nil {
-			resp.Diagnostics.AddError(
-				"Error deleting live sources "+err.Error(),
-				err.Error(),
-			)
+		if _, err := r.client.DeleteLiveSourceWithContext(ctx, &mediatailor.DeleteLiveSourceInput{LiveSourceName: liveSource.LiveSourceName, SourceLocationName: name}); err != nil {
+			addAPIError(&resp.Diagnostics, "Error while deleting live source "+*liveSource.LiveSourceName, err, sourceLocationErrorFields)
 			return
 		}
 	}
 
-	_, err = r.client.DeleteSourceLocation(&mediatailor.DeleteSourceLocationInput{SourceLocationName: name})
+	_, err = r.client.DeleteSourceLocationWithContext(ctx, &mediatailor.DeleteSourceLocationInput{SourceLocationName: name})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting resource "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while deleting source location "+*name, err, sourceLocationErrorFields)
 		return
 	}
 }
 
 func (r *resourceSourceLocation) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	names, err := importNamesFromID(ctx, r.client, req.ID, "sourceLocation")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), names[0])...)
 }
+
+func (r *resourceSourceLocation) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeStateJSON(upgradeSourceLocationStateV0)},
+	}
+}
+
+// upgradeSourceLocationStateV0 converts the timestamps to RFC 3339, which version 1 stores them as.
+var upgradeSourceLocationStateV0 = upgradeTimestamps("creation_time", "last_modified_time")
@@ -8,7 +8,7 @@ import (
 )
 
 func TestAccSourceLocationResource(t *testing.T) {
-	name := "test_source_location"
+	name := "tf-acc-test-source-location"
 	base_url := "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
 	base_url2 := "https://example.com/"
 	k1 := "Environment"
@@ -25,35 +25,50 @@ func TestAccSourceLocationResource(t *testing.T) {
 			{
 				Config: basicSourceLocation(name, base_url, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "id", "test_source_location"),
-					resource.TestMatchResourceAttr("awsmt_source_location.test_source_location", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:sourceLocation\/.*$`)),
-					resource.TestMatchResourceAttr("awsmt_source_location.test_source_location", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "default_segment_delivery_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestMatchResourceAttr("awsmt_source_location.test_source_location", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "segment_delivery_configurations.0.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "name", "test_source_location"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "tags.Testing", "pass"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "tags.Environment", "dev"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "id", "tf-acc-test-source-location"),
+					resource.TestMatchResourceAttr("awsmt_source_location.tf-acc-test-source-location", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:sourceLocation\/.*$`)),
+					resource.TestMatchResourceAttr("awsmt_source_location.tf-acc-test-source-location", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "default_segment_delivery_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
+					resource.TestMatchResourceAttr("awsmt_source_location.tf-acc-test-source-location", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_source_location.tf-acc-test-source-location", "segment_delivery_configurations.*", map[string]string{
+						"base_url": "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com",
+					}),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "name", "tf-acc-test-source-location"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "tags.Testing", "pass"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "tags.Environment", "dev"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName: "awsmt_source_location.test_source_location",
+				ResourceName: "awsmt_source_location.tf-acc-test-source-location",
 				ImportState:  true,
 			},
+			{
+				ResourceName:      "awsmt_source_location.tf-acc-test-source-location",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFromArn("awsmt_source_location.tf-acc-test-source-location", "arn"),
+			},
+			{
+				ResourceName:  "awsmt_source_location.tf-acc-test-source-location",
+				ImportState:   true,
+				ImportStateId: "arn:aws:mediatailor:eu-central-1:000000000000:channel/tf-acc-test-source-location",
+				ExpectError:   regexp.MustCompile("expected an ARN for a sourceLocation"),
+			},
 			// Update and Read testing
 			{
 				Config: basicSourceLocationWithAccessConfig(name, base_url2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "id", "test_source_location"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "access_configuration.access_type", "S3_SIGV4"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "name", "test_source_location"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "default_segment_delivery_configuration.base_url", "https://example.com/"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "segment_delivery_configurations.0.base_url", "https://example.com/"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "tags.Testing", "pass"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "tags.Environment", "prod")),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "id", "tf-acc-test-source-location"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "access_configuration.access_type", "S3_SIGV4"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "name", "tf-acc-test-source-location"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "default_segment_delivery_configuration.base_url", "https://example.com/"),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_source_location.tf-acc-test-source-location", "segment_delivery_configurations.*", map[string]string{
+						"base_url": "https://example.com/",
+					}),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "tags.Testing", "pass"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "tags.Environment", "prod")),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
@@ -68,16 +83,50 @@ func TestAccSourceLocationDeleteVodResource(t *testing.T) {
 			{
 				Config: basicSourceLocationWithVodSource(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "id", "test_source_location"),
-					resource.TestCheckResourceAttr("awsmt_source_location.test_source_location", "name", "test_source_location"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "id", "tf-acc-test-source-location"),
+					resource.TestCheckResourceAttr("awsmt_source_location.tf-acc-test-source-location", "name", "tf-acc-test-source-location"),
 				),
 			},
 		},
 	})
 }
 
+func TestSourceLocationResourceValidation(t *testing.T) {
+	sdc := `[{ base_url = "https://example.com/", name = "default" }]`
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(newFakeMediaTailor("eu-central-1", "123456789012")),
+		Steps: []resource.TestStep{
+			{
+				Config:      validationSourceLocation(`{ access_type = "SECRETS_MANAGER_ACCESS_TOKEN" }`, sdc),
+				ExpectError: regexp.MustCompile("Missing Access Token Configuration"),
+			},
+			{
+				Config:      validationSourceLocation(`{ access_type = "SECRETS_MANAGER_ACCESS_TOKEN", smatc = { header_name = "Authorization", secret_string_key = "token" } }`, sdc),
+				ExpectError: regexp.MustCompile("secret_arn is required"),
+			},
+			{
+				Config:      validationSourceLocation(`{ access_type = "SECRETS_MANAGER_ACCESS_TOKEN", smatc = { header_name = "Authorization", secret_arn = "arn:aws:s3:::bucket", secret_string_key = "token" } }`, sdc),
+				ExpectError: regexp.MustCompile("Invalid Secret ARN"),
+			},
+			{
+				Config:      validationSourceLocation(`{ access_type = "S3_SIGV4", smatc = { header_name = "Authorization" } }`, sdc),
+				ExpectError: regexp.MustCompile("Unexpected Access Token Configuration"),
+			},
+			{
+				Config:      validationSourceLocation(`{ access_type = "S3_SIGV4" }`, `[{ base_url = "https://example.com/", name = "default" }, { base_url = "https://example.org/", name = "default" }]`),
+				ExpectError: regexp.MustCompile("Duplicate Segment Delivery Configuration"),
+			},
+			{
+				Config:      validationSourceLocation(`{ access_type = "S3_SIGV4" }`, `[{ base_url = "http://example.com/", name = "default" }]`),
+				ExpectError: regexp.MustCompile("Invalid Segment Delivery Base URL"),
+			},
+		},
+	})
+}
+
 func basicSourceLocation(name, base_url, k1, v1, k2, v2 string) string {
-	return fmt.Sprintf(`resource "awsmt_source_location" "test_source_location"{
+	return fmt.Sprintf(`resource "awsmt_source_location" "tf-acc-test-source-location"{
   							name = "%[1]s"
   							http_configuration = {
     							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
@@ -95,7 +144,7 @@ func basicSourceLocation(name, base_url, k1, v1, k2, v2 string) string {
 							}
 						}
 						data "awsmt_source_location" "read" {
-  							name = awsmt_source_location.test_source_location.name
+  							name = awsmt_source_location.tf-acc-test-source-location.name
 						}
 						output "awsmt_source_location" {
   							value = data.awsmt_source_location.read
@@ -105,7 +154,7 @@ func basicSourceLocation(name, base_url, k1, v1, k2, v2 string) string {
 }
 
 func basicSourceLocationWithAccessConfig(name, base_url, k1, v1, k2, v2 string) string {
-	return fmt.Sprintf(`resource "awsmt_source_location" "test_source_location"{
+	return fmt.Sprintf(`resource "awsmt_source_location" "tf-acc-test-source-location"{
   							name = "%[1]s"
   							http_configuration = {
     							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
@@ -126,7 +175,7 @@ func basicSourceLocationWithAccessConfig(name, base_url, k1, v1, k2, v2 string)
 							}
 						}
 						data "awsmt_source_location" "read" {
-  							name = awsmt_source_location.test_source_location.name
+  							name = awsmt_source_location.tf-acc-test-source-location.name
 						}
 						output "awsmt_source_location" {
   							value = data.awsmt_source_location.read
@@ -135,8 +184,8 @@ func basicSourceLocationWithAccessConfig(name, base_url, k1, v1, k2, v2 string)
 }
 
 func basicSourceLocationWithVodSource() string {
-	return `resource "awsmt_source_location" "test_source_location"{
-  							name = "test_source_location"
+	return `resource "awsmt_source_location" "tf-acc-test-source-location"{
+  							name = "tf-acc-test-source-location"
   							http_configuration = {
     							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
   							}
@@ -150,7 +199,7 @@ func basicSourceLocationWithVodSource() string {
 							tags = {"Environment": "dev"}
 						}
 						data "awsmt_source_location" "read" {
-  							name = awsmt_source_location.test_source_location.name
+  							name = awsmt_source_location.tf-acc-test-source-location.name
 						}
 						output "awsmt_source_location" {
   							value = data.awsmt_source_location.read
@@ -161,8 +210,21 @@ func basicSourceLocationWithVodSource() string {
 						source_group = "default"
     					type = "HLS"
   					}]
-  					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "vod_source_example"
+  					source_location_name = awsmt_source_location.tf-acc-test-source-location.name
+  					name = "tf-acc-test-vod-source"
 				}
 `
 }
+
+func validationSourceLocation(accessConfiguration, segmentDeliveryConfigurations string) string {
+	return fmt.Sprintf(`
+						resource "awsmt_source_location" "tf-acc-test-source-location"{
+							name = "tf-acc-test-source-location"
+							access_configuration = %[1]s
+							http_configuration = {
+								base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
+							}
+							segment_delivery_configurations = %[2]s
+						}
+						`, accessConfiguration, segmentDeliveryConfigurations)
+}
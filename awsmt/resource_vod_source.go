@@ -2,8 +2,8 @@ package awsmt
 
 import (
 	"context"
-	"fmt"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -11,13 +11,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"reflect"
-	"strings"
 )
 
 var (
-	_ resource.Resource                = &resourceVodSource{}
-	_ resource.ResourceWithConfigure   = &resourceVodSource{}
-	_ resource.ResourceWithImportState = &resourceVodSource{}
+	_ resource.Resource                 = &resourceVodSource{}
+	_ resource.ResourceWithConfigure    = &resourceVodSource{}
+	_ resource.ResourceWithImportState  = &resourceVodSource{}
+	_ resource.ResourceWithUpgradeState = &resourceVodSource{}
 )
 
 func ResourceVodSource() resource.Resource {
@@ -25,7 +25,16 @@ func ResourceVodSource() resource.Resource {
 }
 
 type resourceVodSource struct {
-	client *mediatailor.MediaTailor
+	client mediaTailorClient
+}
+
+// sourceErrorFields maps the fields named in MediaTailor errors to the attributes of VOD and live sources.
+var sourceErrorFields = apiErrorFields{
+	"SourceLocationName":       path.Root("source_location_name"),
+	"VodSourceName":            path.Root("name"),
+	"LiveSourceName":           path.Root("name"),
+	"HttpPackageConfiguration": path.Root("http_package_configurations"),
+	"Tag":                      path.Root("tags"),
 }
 
 func (r *resourceVodSource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -35,12 +44,13 @@ func (r *resourceVodSource) Metadata(_ context.Context, req resource.MetadataReq
 // @ADR
 // Context: The schemas for the VOD Source and the LIVE source are almost identical, except for one field.
 // Decision: We decided to make the duplication undetectable for SonarCloud
-func (r *resourceVodSource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *resourceVodSource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id":                   computedString,
+			"id":                   computedStringKeptUnless(path.MatchRoot("name"), path.MatchRoot("source_location_name")),
 			"source_location_name": requiredString,
-			"http_package_configurations": schema.ListNestedAttribute{
+			"http_package_configurations": schema.SetNestedAttribute{
 				Required: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -54,11 +64,13 @@ func (r *resourceVodSource) Schema(_ context.Context, _ resource.SchemaRequest,
 						},
 					},
 				},
+				Validators: []validator.Set{uniqueKeys("HTTP Package Configuration", "source_group", "type")},
 			},
-			"creation_time":      computedString,
+			"creation_time":      computedTimestampKeptUnless(path.MatchRoot("name"), path.MatchRoot("source_location_name")),
 			"tags":               optionalMap,
-			"last_modified_time": computedString,
-			"arn":                computedString,
+			"timeouts":           timeouts.Attributes(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+			"last_modified_time": computedTimestamp,
+			"arn":                computedStringKeptUnless(path.MatchRoot("name"), path.MatchRoot("source_location_name")),
 			"name":               requiredString,
 			"ad_break_opportunities_offset_millis": schema.ListAttribute{
 				Optional:    true,
@@ -73,7 +85,7 @@ func (r *resourceVodSource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.MediaTailor)
+	r.client = req.ProviderData.(mediaTailorClient)
 }
 
 func (r *resourceVodSource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -85,11 +97,21 @@ func (r *resourceVodSource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_vod_source", "create", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create, defaultCreateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	input := vodSourceInput(plan)
 
-	vodSource, err := r.client.CreateVodSource(&input)
+	vodSource, err := r.client.CreateVodSourceWithContext(ctx, &input)
 	if err != nil {
-		resp.Diagnostics.AddError("Error while creating vod source", err.Error())
+		addAPIError(&resp.Diagnostics, "Error while creating VOD source "+*input.VodSourceName, err, sourceErrorFields)
 		return
 	}
 
@@ -110,6 +132,16 @@ func (r *resourceVodSource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_vod_source", "read", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read, defaultReadTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var sourceLocationName, name string
 
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source_location_name"), &sourceLocationName)...)
@@ -123,9 +155,14 @@ func (r *resourceVodSource) Read(ctx context.Context, req resource.ReadRequest,
 	input.VodSourceName = &name
 	input.SourceLocationName = &sourceLocationName
 
-	vodSource, err := r.client.DescribeVodSource(input)
+	vodSource, err := r.client.DescribeVodSourceWithContext(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing vod source", "Could not describe the vod source: "+*input.SourceLocationName+" and "+*input.VodSourceName+": "+err.Error())
+		if isNotFound(err) {
+			// The resource was deleted outside of Terraform, which plans to create it again.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error while describing VOD source "+name, err, sourceErrorFields)
 		return
 	}
 
@@ -146,13 +183,23 @@ func (r *resourceVodSource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_vod_source", "update", plan.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update, defaultUpdateTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	input := &mediatailor.DescribeVodSourceInput{}
 	input.VodSourceName = plan.Name
 	input.SourceLocationName = plan.SourceLocationName
 
-	vodSource, err := r.client.DescribeVodSource(input)
+	vodSource, err := r.client.DescribeVodSourceWithContext(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Error while describing Vod source", err.Error())
+		addAPIError(&resp.Diagnostics, "Error while describing VOD source "+*plan.Name, err, sourceErrorFields)
 		return
 	}
 
@@ -161,22 +208,18 @@ func (r *resourceVodSource) Update(ctx context.Context, req resource.UpdateReque
 
 	// Check if tags are different
 	if !reflect.DeepEqual(oldTags, newTags) {
-		err = updatesTags(r.client, oldTags, newTags, *vodSource.Arn)
+		err = updatesTags(ctx, r.client, oldTags, newTags, *vodSource.Arn)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error while updating vod source tags"+err.Error(),
-				err.Error(),
-			)
+			addAPIError(&resp.Diagnostics, "Error while updating the tags of VOD source "+*plan.Name, err, sourceErrorFields)
+			return
 		}
 	}
 
 	updateInput := vodSourceUpdateInput(plan)
-	updatedVodSource, err := r.client.UpdateVodSource(&updateInput)
+	updatedVodSource, err := r.client.UpdateVodSourceWithContext(ctx, &updateInput)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while updating vod source "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while updating VOD source "+*plan.Name, err, sourceErrorFields)
+		return
 	}
 
 	plan = readVodSourceToPlan(plan, mediatailor.CreateVodSourceOutput(*updatedVodSource))
@@ -196,30 +239,42 @@ func (r *resourceVodSource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, span := startResourceSpan(ctx, "awsmt_vod_source", "delete", state.Name)
+	defer endResourceSpan(span, &resp.Diagnostics)
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete, defaultDeleteTimeout)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	input := &mediatailor.DeleteVodSourceInput{}
 	input.VodSourceName = state.Name
 	input.SourceLocationName = state.SourceLocationName
 
-	_, err := r.client.DeleteVodSource(input)
+	_, err := r.client.DeleteVodSourceWithContext(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while deleting vod source "+err.Error(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error while deleting VOD source "+*state.Name, err, sourceErrorFields)
 	}
 }
 
 func (r *resourceVodSource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, ",")
-
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: source_location_name, name. Got: %q", req.ID),
-		)
+	idParts, err := importNamesFromID(ctx, r.client, req.ID, "vodSource")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_location_name"), idParts[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), idParts[1])...)
 }
+
+func (r *resourceVodSource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeStateJSON(upgradeVodSourceStateV0)},
+	}
+}
+
+// upgradeVodSourceStateV0 converts the timestamps to RFC 3339, which version 1 stores them as.
+var upgradeVodSourceStateV0 = upgradeTimestamps("creation_time", "last_modified_time")
@@ -8,7 +8,7 @@ import (
 )
 
 func TestAccVodSourceResourceBasic(t *testing.T) {
-	name := "vod_source_example"
+	name := "tf-acc-test-vod-source"
 	path := "/"
 	path2 := "/test"
 	k1 := "Environment"
@@ -24,30 +24,34 @@ func TestAccVodSourceResourceBasic(t *testing.T) {
 			{
 				Config: basicVodSourceWithSourceLocation(name, path, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "id", "test_source_location,vod_source_example"),
+					resource.TestCheckResourceAttr("awsmt_vod_source.test", "id", "tf-acc-test-source-location,tf-acc-test-vod-source"),
 					resource.TestMatchResourceAttr("awsmt_vod_source.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:vodSource\/.*$`)),
-					resource.TestMatchResourceAttr("awsmt_vod_source.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "http_package_configurations.0.path", "/"),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "http_package_configurations.0.source_group", "default"),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr("awsmt_vod_source.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "name", "vod_source_example"),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr("awsmt_vod_source.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_vod_source.test", "http_package_configurations.*", map[string]string{
+						"path":         "/",
+						"source_group": "default",
+						"type":         "HLS",
+					}),
+					resource.TestMatchResourceAttr("awsmt_vod_source.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckResourceAttr("awsmt_vod_source.test", "name", "tf-acc-test-vod-source"),
+					resource.TestCheckResourceAttr("awsmt_vod_source.test", "source_location_name", "tf-acc-test-source-location"),
 					resource.TestCheckResourceAttr("awsmt_vod_source.test", "tags.Environment", "dev"),
 				),
 			},
 			{
 				Config: basicVodSourceWithSourceLocation(name, path2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "id", "test_source_location,vod_source_example"),
+					resource.TestCheckResourceAttr("awsmt_vod_source.test", "id", "tf-acc-test-source-location,tf-acc-test-vod-source"),
 					resource.TestMatchResourceAttr("awsmt_vod_source.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:vodSource\/.*$`)),
-					resource.TestMatchResourceAttr("awsmt_vod_source.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "http_package_configurations.0.path", "/test"),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "http_package_configurations.0.source_group", "default"),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr("awsmt_vod_source.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "name", "vod_source_example"),
-					resource.TestCheckResourceAttr("awsmt_vod_source.test", "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr("awsmt_vod_source.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_vod_source.test", "http_package_configurations.*", map[string]string{
+						"path":         "/test",
+						"source_group": "default",
+						"type":         "HLS",
+					}),
+					resource.TestMatchResourceAttr("awsmt_vod_source.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)),
+					resource.TestCheckResourceAttr("awsmt_vod_source.test", "name", "tf-acc-test-vod-source"),
+					resource.TestCheckResourceAttr("awsmt_vod_source.test", "source_location_name", "tf-acc-test-source-location"),
 					resource.TestCheckResourceAttr("awsmt_vod_source.test", "tags.Environment", "prod"),
 					resource.TestCheckResourceAttr("awsmt_vod_source.test", "tags.Testing", "pass"),
 				),
@@ -56,6 +60,33 @@ func TestAccVodSourceResourceBasic(t *testing.T) {
 	})
 }
 
+func TestAccVodSourceResourceReorderedPackageConfigurations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: orderedVodSource("HLS", "DASH"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_vod_source.test", "http_package_configurations.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_vod_source.test", "http_package_configurations.*", map[string]string{
+						"path": "/hls",
+						"type": "HLS",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("awsmt_vod_source.test", "http_package_configurations.*", map[string]string{
+						"path": "/dash",
+						"type": "DASH",
+					}),
+				),
+			},
+			{
+				Config:   orderedVodSource("DASH", "HLS"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func basicVodSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string {
 	return fmt.Sprintf(`resource "awsmt_vod_source" "test" {
   							http_package_configurations = [{
@@ -63,7 +94,7 @@ func basicVodSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string
 								source_group = "default"
     							type = "HLS"
   							}]
-  							source_location_name = awsmt_source_location.test_source_location.name
+  							source_location_name = awsmt_source_location.tf-acc-test-source-location.name
   							name = "%[1]s"
 							tags = {
    		 						"%[3]s": "%[4]s",
@@ -71,15 +102,15 @@ func basicVodSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string
 							}
 						}
 						data "awsmt_vod_source" "data_test" {
-  							source_location_name = awsmt_source_location.test_source_location.name
+  							source_location_name = awsmt_source_location.tf-acc-test-source-location.name
   							name = awsmt_vod_source.test.name
 						}
 
 						output "vod_source_out" {
   							value = data.awsmt_vod_source.data_test
 						}
-						resource "awsmt_source_location" "test_source_location"{
-  							name = "test_source_location"
+						resource "awsmt_source_location" "tf-acc-test-source-location"{
+  							name = "tf-acc-test-source-location"
   							http_configuration = {
     							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   							}
@@ -88,10 +119,33 @@ func basicVodSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string
   							}
 						}
 						data "awsmt_source_location" "test" {
-  							name = awsmt_source_location.test_source_location.name
+  							name = awsmt_source_location.tf-acc-test-source-location.name
 						}
 						output "awsmt_source_location" {
   							value = data.awsmt_source_location.test
 						}
 						`, name, path, k1, v1, k2, v2)
 }
+
+func orderedVodSource(first, second string) string {
+	return fmt.Sprintf(`resource "awsmt_source_location" "tf-acc-test-source-location"{
+  							name = "tf-acc-test-source-location"
+  							http_configuration = {
+    							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
+  							}
+						}
+						resource "awsmt_vod_source" "test" {
+  							http_package_configurations = [{
+								path = "/${lower("%[1]s")}"
+								source_group = "default"
+    							type = "%[1]s"
+  							}, {
+								path = "/${lower("%[2]s")}"
+								source_group = "default"
+    							type = "%[2]s"
+  							}]
+  							source_location_name = awsmt_source_location.tf-acc-test-source-location.name
+  							name = "tf-acc-test-vod-source"
+						}
+						`, first, second)
+}
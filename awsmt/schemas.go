@@ -1,7 +1,10 @@
 package awsmt
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -13,6 +16,11 @@ var computedString = schema.StringAttribute{
 	Computed: true,
 }
 
+var computedTimestamp = schema.StringAttribute{
+	Computed:   true,
+	CustomType: timestampType{},
+}
+
 var computedInt64 = schema.Int64Attribute{
 	Computed: true,
 }
@@ -35,6 +43,11 @@ var optionalString = schema.StringAttribute{
 	Optional: true,
 }
 
+var optionalHttpUrl = schema.StringAttribute{
+	Optional:   true,
+	Validators: []validator.String{httpUrl()},
+}
+
 var optionalInt64 = schema.Int64Attribute{
 	Optional: true,
 }
@@ -52,3 +65,33 @@ var optionalList = schema.ListAttribute{
 var optionalBool = schema.BoolAttribute{
 	Optional: true,
 }
+
+var optionalComputedString = schema.StringAttribute{
+	Optional: true,
+	Computed: true,
+}
+
+var optionalComputedMap = schema.MapAttribute{
+	Optional:    true,
+	Computed:    true,
+	ElementType: types.StringType,
+}
+
+// computedStringKeptUnless returns a computed string that keeps its prior value in plans, unless one of the given
+// attributes changes.
+func computedStringKeptUnless(expressions ...path.Expression) schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:      true,
+		PlanModifiers: []planmodifier.String{useStateForUnknownUnlessChanged(expressions...)},
+	}
+}
+
+// computedTimestampKeptUnless returns a computed timestamp that keeps its prior value in plans, unless one of the
+// given attributes changes.
+func computedTimestampKeptUnless(expressions ...path.Expression) schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:      true,
+		CustomType:    timestampType{},
+		PlanModifiers: []planmodifier.String{useStateForUnknownUnlessChanged(expressions...)},
+	}
+}
@@ -1,18 +1,71 @@
 package awsmt
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	datasourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type sourceLocationModel struct {
 	ID                                  types.String                              `tfsdk:"id"`
 	AccessConfiguration                 *accessConfigurationModel                 `tfsdk:"access_configuration"`
 	Arn                                 types.String                              `tfsdk:"arn"`
-	CreationTime                        types.String                              `tfsdk:"creation_time"`
+	CreationTime                        timestamp                                 `tfsdk:"creation_time"`
 	DefaultSegmentDeliveryConfiguration *defaultSegmentDeliveryConfigurationModel `tfsdk:"default_segment_delivery_configuration"`
 	HttpConfiguration                   *httpConfigurationModel                   `tfsdk:"http_configuration"`
-	LastModifiedTime                    types.String                              `tfsdk:"last_modified_time"`
+	LastModifiedTime                    timestamp                                 `tfsdk:"last_modified_time"`
 	SegmentDeliveryConfigurations       []segmentDeliveryConfigurationsModel      `tfsdk:"segment_delivery_configurations"`
 	Name                                *string                                   `tfsdk:"name"`
 	Tags                                map[string]*string                        `tfsdk:"tags"`
+	Timeouts                            timeouts.Value                            `tfsdk:"timeouts"`
+}
+
+// sourceLocationDataSourceModel holds the attributes of sourceLocationModel, with the timeouts of a data source.
+type sourceLocationDataSourceModel struct {
+	ID                                  types.String                              `tfsdk:"id"`
+	AccessConfiguration                 *accessConfigurationModel                 `tfsdk:"access_configuration"`
+	Arn                                 types.String                              `tfsdk:"arn"`
+	CreationTime                        timestamp                                 `tfsdk:"creation_time"`
+	DefaultSegmentDeliveryConfiguration *defaultSegmentDeliveryConfigurationModel `tfsdk:"default_segment_delivery_configuration"`
+	HttpConfiguration                   *httpConfigurationModel                   `tfsdk:"http_configuration"`
+	LastModifiedTime                    timestamp                                 `tfsdk:"last_modified_time"`
+	SegmentDeliveryConfigurations       []segmentDeliveryConfigurationsModel      `tfsdk:"segment_delivery_configurations"`
+	Name                                *string                                   `tfsdk:"name"`
+	Tags                                map[string]*string                        `tfsdk:"tags"`
+	Timeouts                            datasourcetimeouts.Value                  `tfsdk:"timeouts"`
+}
+
+// sourceLocationModel returns the data source model without its timeouts, so that it can be read with the helpers of the
+// resource.
+func (m sourceLocationDataSourceModel) sourceLocationModel() sourceLocationModel {
+	return sourceLocationModel{
+		ID:                                  m.ID,
+		AccessConfiguration:                 m.AccessConfiguration,
+		Arn:                                 m.Arn,
+		CreationTime:                        m.CreationTime,
+		DefaultSegmentDeliveryConfiguration: m.DefaultSegmentDeliveryConfiguration,
+		HttpConfiguration:                   m.HttpConfiguration,
+		LastModifiedTime:                    m.LastModifiedTime,
+		SegmentDeliveryConfigurations:       m.SegmentDeliveryConfigurations,
+		Name:                                m.Name,
+		Tags:                                m.Tags,
+	}
+}
+
+func newSourceLocationDataSourceModel(m sourceLocationModel, dataSourceTimeouts datasourcetimeouts.Value) sourceLocationDataSourceModel {
+	return sourceLocationDataSourceModel{
+		ID:                                  m.ID,
+		AccessConfiguration:                 m.AccessConfiguration,
+		Arn:                                 m.Arn,
+		CreationTime:                        m.CreationTime,
+		DefaultSegmentDeliveryConfiguration: m.DefaultSegmentDeliveryConfiguration,
+		HttpConfiguration:                   m.HttpConfiguration,
+		LastModifiedTime:                    m.LastModifiedTime,
+		SegmentDeliveryConfigurations:       m.SegmentDeliveryConfigurations,
+		Name:                                m.Name,
+		Tags:                                m.Tags,
+		Timeouts:                            dataSourceTimeouts,
+	}
 }
 
 type accessConfigurationModel struct {
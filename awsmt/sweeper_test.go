@@ -0,0 +1,237 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"log"
+	"os"
+	"strings"
+)
+
+// @ADR
+// Context: Failed acceptance test runs leave resources behind, and running channels are billed.
+// Decision: We register sweepers for every resource type, which `make sweep` runs. The acceptance tests name every
+// resource they create with the tf-acc-test- prefix, and a sweeper only deletes resources whose name starts with it.
+// The prefix can be overridden with the AWSMT_SWEEP_PREFIXES environment variable (comma-separated).
+// Consequences: Resources created by hand in the test account must not use the prefix. A sweep deletes the resources
+// of concurrent test runs in the same account and region, so the CI test job runs one at a time.
+const defaultSweepPrefixes = "tf-acc-test-"
+
+func init() {
+	resource.AddTestSweepers("awsmt_channel", &resource.Sweeper{
+		Name: "awsmt_channel",
+		F:    sweepChannels,
+	})
+	resource.AddTestSweepers("awsmt_playback_configuration", &resource.Sweeper{
+		Name: "awsmt_playback_configuration",
+		F:    sweepPlaybackConfigurations,
+	})
+	resource.AddTestSweepers("awsmt_vod_source", &resource.Sweeper{
+		Name:         "awsmt_vod_source",
+		Dependencies: []string{"awsmt_channel"},
+		F:            sweepVodSources,
+	})
+	resource.AddTestSweepers("awsmt_live_source", &resource.Sweeper{
+		Name:         "awsmt_live_source",
+		Dependencies: []string{"awsmt_channel"},
+		F:            sweepLiveSources,
+	})
+	resource.AddTestSweepers("awsmt_source_location", &resource.Sweeper{
+		Name:         "awsmt_source_location",
+		Dependencies: []string{"awsmt_channel", "awsmt_vod_source", "awsmt_live_source"},
+		F:            sweepSourceLocations,
+	})
+}
+
+func sweeperClient(region string) (*mediatailor.MediaTailor, error) {
+	sess, err := newSession(region, os.Getenv("AWS_PROFILE"), os.Getenv(endpointEnvVar))
+	if err != nil {
+		return nil, fmt.Errorf("error creating the sweeper session: %w", err)
+	}
+	return mediatailor.New(sess), nil
+}
+
+func sweepPrefixes() []string {
+	prefixes := os.Getenv("AWSMT_SWEEP_PREFIXES")
+	if prefixes == "" {
+		prefixes = defaultSweepPrefixes
+	}
+	return strings.Split(prefixes, ",")
+}
+
+func shouldSweep(names ...*string) bool {
+	for _, name := range names {
+		for _, prefix := range sweepPrefixes() {
+			if prefix != "" && strings.HasPrefix(aws.StringValue(name), prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sweepChannels(region string) error {
+	client, err := sweeperClient(region)
+	if err != nil {
+		return err
+	}
+
+	var channels []*mediatailor.Channel
+	err = client.ListChannelsPages(&mediatailor.ListChannelsInput{}, func(page *mediatailor.ListChannelsOutput, _ bool) bool {
+		channels = append(channels, page.Items...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("error listing channels: %w", err)
+	}
+
+	var errs []error
+	for _, channel := range channels {
+		if !shouldSweep(channel.ChannelName) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping channel %s", *channel.ChannelName)
+		if aws.StringValue(channel.ChannelState) == mediatailor.ChannelStateRunning {
+			if _, err := client.StopChannel(&mediatailor.StopChannelInput{ChannelName: channel.ChannelName}); err != nil {
+				errs = append(errs, fmt.Errorf("error stopping channel %s: %w", *channel.ChannelName, err))
+				continue
+			}
+		}
+		if _, err := client.DeleteChannelPolicy(&mediatailor.DeleteChannelPolicyInput{ChannelName: channel.ChannelName}); err != nil && !isNotFound(err) {
+			errs = append(errs, fmt.Errorf("error deleting the policy of channel %s: %w", *channel.ChannelName, err))
+			continue
+		}
+		if _, err := client.DeleteChannel(&mediatailor.DeleteChannelInput{ChannelName: channel.ChannelName}); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting channel %s: %w", *channel.ChannelName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepPlaybackConfigurations(region string) error {
+	client, err := sweeperClient(region)
+	if err != nil {
+		return err
+	}
+
+	var names []*string
+	err = client.ListPlaybackConfigurationsPages(&mediatailor.ListPlaybackConfigurationsInput{}, func(page *mediatailor.ListPlaybackConfigurationsOutput, _ bool) bool {
+		for _, playbackConfiguration := range page.Items {
+			names = append(names, playbackConfiguration.Name)
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("error listing playback configurations: %w", err)
+	}
+
+	var errs []error
+	for _, name := range names {
+		if !shouldSweep(name) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping playback configuration %s", *name)
+		if _, err := client.DeletePlaybackConfiguration(&mediatailor.DeletePlaybackConfigurationInput{Name: name}); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting playback configuration %s: %w", *name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepVodSources(region string) error {
+	client, err := sweeperClient(region)
+	if err != nil {
+		return err
+	}
+
+	sourceLocationNames, err := listSourceLocationNames(context.Background(), client, nil)
+	if err != nil {
+		return fmt.Errorf("error listing source locations: %w", err)
+	}
+
+	var errs []error
+	for _, sourceLocationName := range sourceLocationNames {
+		var vodSources []*mediatailor.VodSource
+		err := client.ListVodSourcesPages(&mediatailor.ListVodSourcesInput{SourceLocationName: sourceLocationName}, func(page *mediatailor.ListVodSourcesOutput, _ bool) bool {
+			vodSources = append(vodSources, page.Items...)
+			return true
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error listing vod sources of source location %s: %w", *sourceLocationName, err))
+			continue
+		}
+		for _, vodSource := range vodSources {
+			if !shouldSweep(sourceLocationName, vodSource.VodSourceName) {
+				continue
+			}
+			log.Printf("[INFO] Sweeping vod source %s,%s", *sourceLocationName, *vodSource.VodSourceName)
+			if _, err := client.DeleteVodSource(&mediatailor.DeleteVodSourceInput{SourceLocationName: sourceLocationName, VodSourceName: vodSource.VodSourceName}); err != nil {
+				errs = append(errs, fmt.Errorf("error deleting vod source %s,%s: %w", *sourceLocationName, *vodSource.VodSourceName, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepLiveSources(region string) error {
+	client, err := sweeperClient(region)
+	if err != nil {
+		return err
+	}
+
+	sourceLocationNames, err := listSourceLocationNames(context.Background(), client, nil)
+	if err != nil {
+		return fmt.Errorf("error listing source locations: %w", err)
+	}
+
+	var errs []error
+	for _, sourceLocationName := range sourceLocationNames {
+		var liveSources []*mediatailor.LiveSource
+		err := client.ListLiveSourcesPages(&mediatailor.ListLiveSourcesInput{SourceLocationName: sourceLocationName}, func(page *mediatailor.ListLiveSourcesOutput, _ bool) bool {
+			liveSources = append(liveSources, page.Items...)
+			return true
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error listing live sources of source location %s: %w", *sourceLocationName, err))
+			continue
+		}
+		for _, liveSource := range liveSources {
+			if !shouldSweep(sourceLocationName, liveSource.LiveSourceName) {
+				continue
+			}
+			log.Printf("[INFO] Sweeping live source %s,%s", *sourceLocationName, *liveSource.LiveSourceName)
+			if _, err := client.DeleteLiveSource(&mediatailor.DeleteLiveSourceInput{SourceLocationName: sourceLocationName, LiveSourceName: liveSource.LiveSourceName}); err != nil {
+				errs = append(errs, fmt.Errorf("error deleting live source %s,%s: %w", *sourceLocationName, *liveSource.LiveSourceName, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepSourceLocations(region string) error {
+	client, err := sweeperClient(region)
+	if err != nil {
+		return err
+	}
+
+	sourceLocationNames, err := listSourceLocationNames(context.Background(), client, nil)
+	if err != nil {
+		return fmt.Errorf("error listing source locations: %w", err)
+	}
+
+	var errs []error
+	for _, sourceLocationName := range sourceLocationNames {
+		if !shouldSweep(sourceLocationName) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping source location %s", *sourceLocationName)
+		if _, err := client.DeleteSourceLocation(&mediatailor.DeleteSourceLocationInput{SourceLocationName: sourceLocationName}); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting source location %s: %w", *sourceLocationName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
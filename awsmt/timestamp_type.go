@@ -0,0 +1,154 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"strings"
+	"time"
+)
+
+var (
+	_ basetypes.StringTypable                    = (*timestampType)(nil)
+	_ xattr.TypeWithValidate                     = (*timestampType)(nil)
+	_ basetypes.StringValuable                   = (*timestamp)(nil)
+	_ basetypes.StringValuableWithSemanticEquals = (*timestamp)(nil)
+)
+
+// @ADR
+// Context: Timestamps used to be stored with time.Time.String(), e.g. "2023-10-01 12:00:00.123 +0000 UTC", which
+// Terraform functions such as timecmp and timeadd cannot parse.
+// Decision: We decided to store every timestamp as RFC 3339 in UTC, through timestampValue and a custom type
+// comparing timestamps by the instant they describe.
+// Consequences: The schema versions of the resources with timestamps were bumped, and their state upgraders
+// convert the stored values.
+
+// timestampType is an RFC 3339 string type whose values are equal if they describe the same instant.
+type timestampType struct {
+	basetypes.StringType
+}
+
+func (t timestampType) String() string {
+	return "awsmt.timestampType"
+}
+
+func (t timestampType) ValueType(_ context.Context) attr.Value {
+	return timestamp{}
+}
+
+func (t timestampType) Equal(o attr.Type) bool {
+	other, ok := o.(timestampType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t timestampType) Validate(_ context.Context, in tftypes.Value, path path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if in.Type() == nil || !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var value string
+	if err := in.As(&value); err != nil {
+		diags.AddAttributeError(path, "Timestamp Type Validation Error", err.Error())
+		return diags
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		diags.AddAttributeError(path, "Invalid RFC 3339 Timestamp", "Expected a timestamp like 2023-10-01T12:00:00Z, got "+value)
+	}
+	return diags
+}
+
+func (t timestampType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return timestamp{StringValue: in}, nil
+}
+
+func (t timestampType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return timestamp{StringValue: stringValue}, nil
+}
+
+// timestamp is a value of timestampType.
+type timestamp struct {
+	basetypes.StringValue
+}
+
+// timestampValue converts a timestamp returned by the SDK to its RFC 3339 representation in UTC, or null if the SDK
+// did not return one.
+func timestampValue(t *time.Time) timestamp {
+	if t == nil {
+		return timestamp{StringValue: basetypes.NewStringNull()}
+	}
+	return timestamp{StringValue: basetypes.NewStringValue(t.UTC().Format(time.RFC3339Nano))}
+}
+
+func (v timestamp) Type(_ context.Context) attr.Type {
+	return timestampType{}
+}
+
+func (v timestamp) Equal(o attr.Value) bool {
+	other, ok := o.(timestamp)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v timestamp) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(timestamp)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, newValuable))
+		return false, diags
+	}
+
+	a, errA := time.Parse(time.RFC3339, v.ValueString())
+	b, errB := time.Parse(time.RFC3339, newValue.ValueString())
+	if errA != nil || errB != nil {
+		return v.ValueString() == newValue.ValueString(), diags
+	}
+	return a.Equal(b), diags
+}
+
+// goTimeStringLayout is the layout of time.Time.String(), which the provider used for timestamps before they were
+// stored as RFC 3339.
+const goTimeStringLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// upgradeTimestamps returns a state upgrade converting the given attributes from the layout of time.Time.String()
+// to RFC 3339. Values that are null or already RFC 3339 are kept.
+func upgradeTimestamps(attributes ...string) func(state map[string]interface{}) error {
+	return func(state map[string]interface{}) error {
+		for _, attribute := range attributes {
+			value, ok := state[attribute].(string)
+			if !ok {
+				continue
+			}
+			if _, err := time.Parse(time.RFC3339, value); err == nil {
+				continue
+			}
+			// Drop the monotonic clock reading time.Time.String() appends to times read from the local clock.
+			value, _, _ = strings.Cut(value, " m=")
+			parsed, err := time.Parse(goTimeStringLayout, value)
+			if err != nil {
+				return fmt.Errorf("unable to convert %s %q to RFC 3339: %w", attribute, value, err)
+			}
+			state[attribute] = parsed.UTC().Format(time.RFC3339Nano)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"testing"
+	"time"
+)
+
+func TestTimestampValue(t *testing.T) {
+	value := time.Date(2023, 11, 8, 11, 15, 42, 123000000, time.FixedZone("CET", 3600))
+	if got := timestampValue(&value).ValueString(); got != "2023-11-08T10:15:42.123Z" {
+		t.Errorf("expected an RFC 3339 timestamp in UTC, got %q", got)
+	}
+	if !timestampValue(nil).IsNull() {
+		t.Error("expected a null timestamp")
+	}
+}
+
+func TestTimestampSemanticEquals(t *testing.T) {
+	a := timestamp{StringValue: basetypes.NewStringValue("2023-11-08T10:15:42Z")}
+	b := timestamp{StringValue: basetypes.NewStringValue("2023-11-08T11:15:42+01:00")}
+
+	equal, diags := a.StringSemanticEquals(context.Background(), b)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !equal {
+		t.Error("expected the timestamps to be equal")
+	}
+}
+
+func TestUpgradeTimestamps(t *testing.T) {
+	state := map[string]interface{}{
+		"creation_time":      "2023-11-08 11:15:42.123 +0100 CET",
+		"last_modified_time": "2023-11-08 10:15:42.123456789 +0000 UTC m=+0.000000001",
+		"start_time":         "2023-11-08T10:15:42Z",
+		"end_time":           nil,
+	}
+	if err := upgradeTimestamps("creation_time", "last_modified_time", "start_time", "end_time")(state); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"creation_time":      "2023-11-08T10:15:42.123Z",
+		"last_modified_time": "2023-11-08T10:15:42.123456789Z",
+		"start_time":         "2023-11-08T10:15:42Z",
+		"end_time":           nil,
+	}
+	for key, value := range expected {
+		if state[key] != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, state[key])
+		}
+	}
+
+	if err := upgradeTimestamps("creation_time")(map[string]interface{}{"creation_time": "yesterday"}); err == nil {
+		t.Error("expected an error for a timestamp in an unknown format")
+	}
+}
@@ -0,0 +1,274 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+	"strings"
+)
+
+// adsUrlTemplate validates the dynamic variables of an ad decision server URL template.
+func adsUrlTemplate() validator.String {
+	return adsUrlTemplateValidator{}
+}
+
+type adsUrlTemplateValidator struct{}
+
+func (v adsUrlTemplateValidator) Description(_ context.Context) string {
+	return "value must be a URL template using only the session, avail, scte and player_params dynamic variables"
+}
+
+func (v adsUrlTemplateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v adsUrlTemplateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseAdsUrlTemplate(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Ad Decision Server URL", err.Error())
+	}
+}
+
+// maxConfigurationAliasParameters and maxConfigurationAliasesPerParameter bound the size of configuration_aliases.
+// They are the configuration alias quotas of MediaTailor, see https://docs.aws.amazon.com/mediatailor/latest/ug/quotas.html,
+// and need to be updated when the quotas change.
+const (
+	maxConfigurationAliasParameters     = 10
+	maxConfigurationAliasesPerParameter = 50
+)
+
+var configurationAliasKey = regexp.MustCompile(`^player_params\.[A-Za-z0-9_-]+$`)
+
+// configurationAliases validates the structure of configuration_aliases: every key names a player parameter and maps
+// a non-empty set of aliases to non-empty values.
+func configurationAliases() validator.Map {
+	return configurationAliasesValidator{}
+}
+
+type configurationAliasesValidator struct{}
+
+func (v configurationAliasesValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("keys must have the format player_params.<name>, with at most %d parameters and %d aliases each", maxConfigurationAliasParameters, maxConfigurationAliasesPerParameter)
+}
+
+func (v configurationAliasesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v configurationAliasesValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	parameters := req.ConfigValue.Elements()
+	if len(parameters) > maxConfigurationAliasParameters {
+		resp.Diagnostics.AddAttributeError(req.Path, "Too Many Configuration Aliases",
+			fmt.Sprintf("At most %d player parameters can have aliases, got %d.", maxConfigurationAliasParameters, len(parameters)))
+	}
+
+	for key, value := range parameters {
+		keyPath := req.Path.AtMapKey(key)
+		if !configurationAliasKey.MatchString(key) {
+			resp.Diagnostics.AddAttributeError(keyPath, "Invalid Configuration Alias Key",
+				fmt.Sprintf("Keys must have the format player_params.<name>, where the name consists of letters, digits, '_' and '-'. Got %q.", key))
+		}
+
+		aliases, ok := value.(types.Map)
+		if !ok || aliases.IsUnknown() {
+			continue
+		}
+		if len(aliases.Elements()) == 0 {
+			resp.Diagnostics.AddAttributeError(keyPath, "Empty Configuration Alias", "Each player parameter needs at least one alias.")
+		}
+		if len(aliases.Elements()) > maxConfigurationAliasesPerParameter {
+			resp.Diagnostics.AddAttributeError(keyPath, "Too Many Configuration Aliases",
+				fmt.Sprintf("At most %d aliases can be defined per player parameter, got %d.", maxConfigurationAliasesPerParameter, len(aliases.Elements())))
+		}
+
+		for alias, aliasValue := range aliases.Elements() {
+			aliasPath := keyPath.AtMapKey(alias)
+			if alias == "" {
+				resp.Diagnostics.AddAttributeError(aliasPath, "Invalid Configuration Alias", "Alias names must not be empty.")
+			}
+			if s, ok := aliasValue.(types.String); ok && !s.IsUnknown() && s.ValueString() == "" {
+				resp.Diagnostics.AddAttributeError(aliasPath, "Invalid Configuration Alias", "The value of alias \""+alias+"\" must not be empty.")
+			}
+		}
+	}
+}
+
+// httpUrl validates that a value is an absolute HTTP or HTTPS URL with a host. Dynamic variables are allowed in the
+// host, so only the scheme and the presence of a host are checked.
+func httpUrl() validator.String {
+	return stringvalidator.RegexMatches(regexp.MustCompile(`^https?://[^/?#]+`), "must be an absolute http:// or https:// URL")
+}
+
+var secretsManagerArn = regexp.MustCompile(`^arn:aws[a-z-]*:secretsmanager:[a-z0-9-]+:\d{12}:secret:.+$`)
+
+// accessConfigurationValidator checks that the Secrets Manager access token configuration of a source location is
+// given exactly when the access type needs it.
+type accessConfigurationValidator struct{}
+
+func (v accessConfigurationValidator) Description(_ context.Context) string {
+	return "smatc must be set with header_name, secret_arn and secret_string_key if access_type is SECRETS_MANAGER_ACCESS_TOKEN, and must not be set otherwise"
+}
+
+func (v accessConfigurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v accessConfigurationValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	root := path.Root("access_configuration")
+	smatcPath := root.AtName("smatc")
+
+	var accessType types.String
+	var smatc types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, root.AtName("access_type"), &accessType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, smatcPath, &smatc)...)
+	if resp.Diagnostics.HasError() || accessType.IsUnknown() || smatc.IsUnknown() {
+		return
+	}
+
+	if accessType.ValueString() != "SECRETS_MANAGER_ACCESS_TOKEN" {
+		if !smatc.IsNull() {
+			resp.Diagnostics.AddAttributeError(smatcPath, "Unexpected Access Token Configuration",
+				"smatc can only be set if access_type is SECRETS_MANAGER_ACCESS_TOKEN.")
+		}
+		return
+	}
+
+	if smatc.IsNull() {
+		resp.Diagnostics.AddAttributeError(smatcPath, "Missing Access Token Configuration",
+			"smatc with header_name, secret_arn and secret_string_key is required if access_type is SECRETS_MANAGER_ACCESS_TOKEN.")
+		return
+	}
+
+	for _, name := range []string{"header_name", "secret_arn", "secret_string_key"} {
+		value, _ := smatc.Attributes()[name].(types.String)
+		if value.IsNull() {
+			resp.Diagnostics.AddAttributeError(smatcPath.AtName(name), "Missing Access Token Configuration",
+				name+" is required if access_type is SECRETS_MANAGER_ACCESS_TOKEN.")
+			continue
+		}
+		if name == "secret_arn" && !value.IsUnknown() && !secretsManagerArn.MatchString(value.ValueString()) {
+			resp.Diagnostics.AddAttributeError(smatcPath.AtName(name), "Invalid Secret ARN",
+				"Expected the ARN of a Secrets Manager secret, e.g. arn:aws:secretsmanager:eu-central-1:123456789012:secret:example. Got: "+value.ValueString())
+		}
+	}
+}
+
+// segmentDeliveryConfigurationsValidator checks that the segment delivery configurations of a source location have
+// HTTPS base URLs and unique, non-empty names. Configurations without a name are allowed.
+type segmentDeliveryConfigurationsValidator struct{}
+
+func (v segmentDeliveryConfigurationsValidator) Description(_ context.Context) string {
+	return "segment_delivery_configurations must have https:// base URLs and unique, non-empty names"
+}
+
+func (v segmentDeliveryConfigurationsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v segmentDeliveryConfigurationsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	root := path.Root("segment_delivery_configurations")
+
+	var configurations types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, root, &configurations)...)
+	if resp.Diagnostics.HasError() || configurations.IsNull() || configurations.IsUnknown() {
+		return
+	}
+
+	names := map[string]bool{}
+	for _, element := range configurations.Elements() {
+		configuration, ok := element.(types.Object)
+		if !ok || configuration.IsNull() || configuration.IsUnknown() {
+			continue
+		}
+		configurationPath := root.AtSetValue(element)
+
+		baseUrl, _ := configuration.Attributes()["base_url"].(types.String)
+		if !baseUrl.IsNull() && !baseUrl.IsUnknown() && !strings.HasPrefix(baseUrl.ValueString(), "https://") {
+			resp.Diagnostics.AddAttributeError(configurationPath.AtName("base_url"), "Invalid Segment Delivery Base URL",
+				"Segment delivery base URLs must start with https://. Got: "+baseUrl.ValueString())
+		}
+
+		name, _ := configuration.Attributes()["name"].(types.String)
+		if name.IsNull() || name.IsUnknown() {
+			continue
+		}
+		if name.ValueString() == "" {
+			resp.Diagnostics.AddAttributeError(configurationPath.AtName("name"), "Invalid Segment Delivery Name",
+				"Segment delivery configuration names must not be empty.")
+			continue
+		}
+		if names[name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(configurationPath, "Duplicate Segment Delivery Configuration",
+				fmt.Sprintf("Another segment delivery configuration already uses name %q.", name.ValueString()))
+		}
+		names[name.ValueString()] = true
+	}
+}
+
+// uniqueKeys validates that no two elements of a set of nested objects share the values of the given key attributes.
+// MediaTailor identifies such elements by their key, so two elements with the same key but different settings would
+// be rejected or merged by the API. Elements with an unknown or null key are skipped, such as HTTP package
+// configurations without a source group.
+func uniqueKeys(element string, keys ...string) validator.Set {
+	return uniqueKeysValidator{element: element, keys: keys}
+}
+
+type uniqueKeysValidator struct {
+	element string
+	keys    []string
+}
+
+func (v uniqueKeysValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("elements must have unique values for %s", strings.Join(v.keys, " and "))
+}
+
+func (v uniqueKeysValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uniqueKeysValidator) ValidateSet(_ context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, element := range req.ConfigValue.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+
+		var parts []string
+		complete := true
+		for _, key := range v.keys {
+			value, _ := object.Attributes()[key].(types.String)
+			if value.IsUnknown() || value.IsNull() {
+				complete = false
+				break
+			}
+			parts = append(parts, fmt.Sprintf("%s %q", key, value.ValueString()))
+		}
+		if !complete {
+			continue
+		}
+
+		key := strings.Join(parts, " and ")
+		if seen[key] {
+			resp.Diagnostics.AddAttributeError(req.Path.AtSetValue(element), "Duplicate "+v.element,
+				fmt.Sprintf("Another %s already uses %s.", strings.ToLower(v.element), key))
+		}
+		seen[key] = true
+	}
+}
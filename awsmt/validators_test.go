@@ -0,0 +1,261 @@
+package awsmt
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"reflect"
+	"testing"
+)
+
+func aliasesValue(aliases map[string]map[string]string) types.Map {
+	elements := map[string]attr.Value{}
+	for key, values := range aliases {
+		inner := map[string]attr.Value{}
+		for alias, value := range values {
+			inner[alias] = types.StringValue(value)
+		}
+		elements[key] = types.MapValueMust(types.StringType, inner)
+	}
+	return types.MapValueMust(types.MapType{ElemType: types.StringType}, elements)
+}
+
+func TestConfigurationAliasesValidator(t *testing.T) {
+	root := path.Root("configuration_aliases")
+	for _, c := range []struct {
+		aliases map[string]map[string]string
+		errors  []path.Path
+	}{
+		{
+			aliases: map[string]map[string]string{"player_params.origin_domain": {"pdx": "origin-pdx.example.com", "iad": "origin-iad.example.com"}},
+		},
+		{
+			aliases: map[string]map[string]string{"origin_domain": {"pdx": "origin-pdx.example.com"}},
+			errors:  []path.Path{root.AtMapKey("origin_domain")},
+		},
+		{
+			aliases: map[string]map[string]string{"player_params.origin_domain": {}},
+			errors:  []path.Path{root.AtMapKey("player_params.origin_domain")},
+		},
+		{
+			aliases: map[string]map[string]string{"player_params.origin_domain": {"pdx": ""}},
+			errors:  []path.Path{root.AtMapKey("player_params.origin_domain").AtMapKey("pdx")},
+		},
+	} {
+		resp := validator.MapResponse{}
+		configurationAliases().ValidateMap(context.Background(), validator.MapRequest{Path: root, ConfigValue: aliasesValue(c.aliases)}, &resp)
+
+		if resp.Diagnostics.ErrorsCount() != len(c.errors) {
+			t.Errorf("expected %d error(s) for %v, got %v", len(c.errors), c.aliases, resp.Diagnostics)
+			continue
+		}
+		for i, d := range resp.Diagnostics.Errors() {
+			if p := d.(interface{ Path() path.Path }).Path(); !p.Equal(c.errors[i]) {
+				t.Errorf("expected error at %s, got %s", c.errors[i], p)
+			}
+		}
+	}
+}
+
+func TestConfigurationAliasesValidatorLimits(t *testing.T) {
+	aliases := map[string]map[string]string{}
+	for i := 0; i <= maxConfigurationAliasParameters; i++ {
+		aliases["player_params.p"+string(rune('a'+i))] = map[string]string{"a": "b"}
+	}
+
+	resp := validator.MapResponse{}
+	configurationAliases().ValidateMap(context.Background(), validator.MapRequest{Path: path.Root("configuration_aliases"), ConfigValue: aliasesValue(aliases)}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for too many player parameters")
+	}
+}
+
+// validatePlaybackConfiguration runs ValidateConfig of the playback configuration resource for the given attributes,
+// with all others null, and returns the summaries of the errors.
+func validatePlaybackConfiguration(t *testing.T, attributes map[string]interface{}) []string {
+	t.Helper()
+	ctx := context.Background()
+	r := &resourcePlaybackConfiguration{}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := tftypes.ValueFromJSON(encoded, schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := resource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}, &resp)
+
+	var summaries []string
+	for _, d := range resp.Diagnostics.Errors() {
+		summaries = append(summaries, d.Summary())
+	}
+	return summaries
+}
+
+func TestPlaybackConfigurationUnusedAliases(t *testing.T) {
+	aliases := map[string]interface{}{"player_params.origin_domain": map[string]string{"pdx": "origin-pdx.example.com"}}
+	for url, expected := range map[string][]string{
+		"https://[player_params.origin_domain]/ads": nil,
+		"https://example.com/ads":                   {"Unused Configuration Alias"},
+		// The syntax error is reported by the attribute validator, and the alias might be used after the error.
+		"https://example.com/ads?id=[session.id&d=[player_params.origin_domain]": nil,
+	} {
+		summaries := validatePlaybackConfiguration(t, map[string]interface{}{
+			"name":                     "test",
+			"ad_decision_server_url":   url,
+			"video_content_source_url": "https://example.com/",
+			"configuration_aliases":    aliases,
+		})
+		if len(summaries) != len(expected) || (len(expected) > 0 && summaries[0] != expected[0]) {
+			t.Errorf("expected the errors %v for %s, got %v", expected, url, summaries)
+		}
+	}
+}
+
+// validateSourceLocation runs ValidateResource of the config validators of the source location resource for the given
+// attributes, with all others null, and returns the summaries of the errors.
+func validateSourceLocation(t *testing.T, attributes map[string]interface{}) []string {
+	t.Helper()
+	ctx := context.Background()
+	r := &resourceSourceLocation{}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := tftypes.ValueFromJSON(encoded, schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}
+
+	var summaries []string
+	for _, v := range r.ConfigValidators(ctx) {
+		resp := resource.ValidateConfigResponse{}
+		v.ValidateResource(ctx, req, &resp)
+		for _, d := range resp.Diagnostics.Errors() {
+			summaries = append(summaries, d.Summary())
+		}
+	}
+	return summaries
+}
+
+func TestAccessConfigurationValidator(t *testing.T) {
+	smatc := map[string]interface{}{
+		"header_name":       "Authorization",
+		"secret_arn":        "arn:aws:secretsmanager:eu-central-1:123456789012:secret:example",
+		"secret_string_key": "token",
+	}
+	for description, c := range map[string]struct {
+		accessConfiguration map[string]interface{}
+		expected            []string
+	}{
+		"no access configuration":                    {nil, nil},
+		"SECRETS_MANAGER_ACCESS_TOKEN with smatc":    {map[string]interface{}{"access_type": "SECRETS_MANAGER_ACCESS_TOKEN", "smatc": smatc}, nil},
+		"SECRETS_MANAGER_ACCESS_TOKEN without smatc": {map[string]interface{}{"access_type": "SECRETS_MANAGER_ACCESS_TOKEN"}, []string{"Missing Access Token Configuration"}},
+		"SECRETS_MANAGER_ACCESS_TOKEN without a secret": {
+			map[string]interface{}{"access_type": "SECRETS_MANAGER_ACCESS_TOKEN", "smatc": map[string]interface{}{"header_name": "Authorization"}},
+			[]string{"Missing Access Token Configuration", "Missing Access Token Configuration"},
+		},
+		"SECRETS_MANAGER_ACCESS_TOKEN with an S3 ARN": {
+			map[string]interface{}{"access_type": "SECRETS_MANAGER_ACCESS_TOKEN", "smatc": map[string]interface{}{"header_name": "Authorization", "secret_arn": "arn:aws:s3:::bucket", "secret_string_key": "token"}},
+			[]string{"Invalid Secret ARN"},
+		},
+		"S3_SIGV4 with smatc":       {map[string]interface{}{"access_type": "S3_SIGV4", "smatc": smatc}, []string{"Unexpected Access Token Configuration"}},
+		"no access type with smatc": {map[string]interface{}{"smatc": smatc}, []string{"Unexpected Access Token Configuration"}},
+	} {
+		got := validateSourceLocation(t, map[string]interface{}{"name": "test", "access_configuration": c.accessConfiguration})
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("expected %v for %s, got %v", c.expected, description, got)
+		}
+	}
+}
+
+func TestSegmentDeliveryConfigurationsValidator(t *testing.T) {
+	configuration := func(baseUrl, name interface{}) map[string]interface{} {
+		return map[string]interface{}{"base_url": baseUrl, "name": name}
+	}
+	for description, c := range map[string]struct {
+		configurations []interface{}
+		expected       []string
+	}{
+		"unique names":      {[]interface{}{configuration("https://example.com/", "default"), configuration("https://example.org/", "other")}, nil},
+		"duplicate names":   {[]interface{}{configuration("https://example.com/", "default"), configuration("https://example.org/", "default")}, []string{"Duplicate Segment Delivery Configuration"}},
+		"an empty name":     {[]interface{}{configuration("https://example.com/", "")}, []string{"Invalid Segment Delivery Name"}},
+		"no names":          {[]interface{}{configuration("https://example.com/", nil), configuration("https://example.org/", nil)}, nil},
+		"an HTTP base URL":  {[]interface{}{configuration("http://example.com/", "default")}, []string{"Invalid Segment Delivery Base URL"}},
+		"no configurations": {nil, nil},
+	} {
+		got := validateSourceLocation(t, map[string]interface{}{"name": "test", "segment_delivery_configurations": c.configurations})
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("expected %v for %s, got %v", c.expected, description, got)
+		}
+	}
+}
+
+func TestHttpUrlValidator(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"https://example.com/":                       true,
+		"http://example.com":                         true,
+		"https://[player_params.domain].example.com": true,
+		"example.com/ads":                            false,
+		"ftp://example.com/":                         false,
+		"https:///ads":                               false,
+	} {
+		resp := validator.StringResponse{}
+		httpUrl().ValidateString(context.Background(), validator.StringRequest{Path: path.Root("url"), ConfigValue: types.StringValue(value)}, &resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("expected %q to be valid: %t", value, valid)
+		}
+	}
+}
+
+func TestUniqueKeysValidator(t *testing.T) {
+	attributeTypes := map[string]attr.Type{"path": types.StringType, "source_group": types.StringType, "type": types.StringType}
+	configuration := func(p, sourceGroup, packageType string) attr.Value {
+		return types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+			"path":         types.StringValue(p),
+			"source_group": types.StringValue(sourceGroup),
+			"type":         types.StringValue(packageType),
+		})
+	}
+	unnamed := func(p string) attr.Value {
+		return types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+			"path":         types.StringValue(p),
+			"source_group": types.StringNull(),
+			"type":         types.StringValue("HLS"),
+		})
+	}
+	root := path.Root("http_package_configurations")
+
+	for _, c := range []struct {
+		elements []attr.Value
+		errors   int
+	}{
+		{elements: []attr.Value{configuration("/", "default", "HLS"), configuration("/", "default", "DASH")}},
+		{elements: []attr.Value{configuration("/", "default", "HLS"), configuration("/other", "default", "HLS")}, errors: 1},
+		{elements: []attr.Value{configuration("/", "default", "HLS"), types.ObjectUnknown(attributeTypes)}},
+		{elements: []attr.Value{unnamed("/"), unnamed("/other")}},
+	} {
+		resp := validator.SetResponse{}
+		value := types.SetValueMust(types.ObjectType{AttrTypes: attributeTypes}, c.elements)
+		uniqueKeys("HTTP Package Configuration", "source_group", "type").ValidateSet(context.Background(), validator.SetRequest{Path: root, ConfigValue: value}, &resp)
+
+		if resp.Diagnostics.ErrorsCount() != c.errors {
+			t.Errorf("expected %d error(s) for %v, got %v", c.errors, c.elements, resp.Diagnostics)
+		}
+	}
+}
@@ -1,17 +1,65 @@
 package awsmt
 
 import (
+	datasourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type vodSourceModel struct {
 	ID                               types.String                     `tfsdk:"id"`
 	Arn                              types.String                     `tfsdk:"arn"`
-	CreationTime                     types.String                     `tfsdk:"creation_time"`
+	CreationTime                     timestamp                        `tfsdk:"creation_time"`
 	HttpPackageConfigurations        []httpPackageConfigurationsModel `tfsdk:"http_package_configurations"`
-	LastModifiedTime                 types.String                     `tfsdk:"last_modified_time"`
+	LastModifiedTime                 timestamp                        `tfsdk:"last_modified_time"`
 	SourceLocationName               *string                          `tfsdk:"source_location_name"`
 	Tags                             map[string]*string               `tfsdk:"tags"`
 	Name                             *string                          `tfsdk:"name"`
 	AdBreakOpportunitiesOffsetMillis []*int64                         `tfsdk:"ad_break_opportunities_offset_millis"`
+	Timeouts                         timeouts.Value                   `tfsdk:"timeouts"`
+}
+
+// vodSourceDataSourceModel holds the attributes of vodSourceModel, with the timeouts of a data source.
+type vodSourceDataSourceModel struct {
+	ID                               types.String                     `tfsdk:"id"`
+	Arn                              types.String                     `tfsdk:"arn"`
+	CreationTime                     timestamp                        `tfsdk:"creation_time"`
+	HttpPackageConfigurations        []httpPackageConfigurationsModel `tfsdk:"http_package_configurations"`
+	LastModifiedTime                 timestamp                        `tfsdk:"last_modified_time"`
+	SourceLocationName               *string                          `tfsdk:"source_location_name"`
+	Tags                             map[string]*string               `tfsdk:"tags"`
+	Name                             *string                          `tfsdk:"name"`
+	AdBreakOpportunitiesOffsetMillis []*int64                         `tfsdk:"ad_break_opportunities_offset_millis"`
+	Timeouts                         datasourcetimeouts.Value         `tfsdk:"timeouts"`
+}
+
+// vodSourceModel returns the data source model without its timeouts, so that it can be read with the helpers of the
+// resource.
+func (m vodSourceDataSourceModel) vodSourceModel() vodSourceModel {
+	return vodSourceModel{
+		ID:                               m.ID,
+		Arn:                              m.Arn,
+		CreationTime:                     m.CreationTime,
+		HttpPackageConfigurations:        m.HttpPackageConfigurations,
+		LastModifiedTime:                 m.LastModifiedTime,
+		SourceLocationName:               m.SourceLocationName,
+		Tags:                             m.Tags,
+		Name:                             m.Name,
+		AdBreakOpportunitiesOffsetMillis: m.AdBreakOpportunitiesOffsetMillis,
+	}
+}
+
+func newVodSourceDataSourceModel(m vodSourceModel, dataSourceTimeouts datasourcetimeouts.Value) vodSourceDataSourceModel {
+	return vodSourceDataSourceModel{
+		ID:                               m.ID,
+		Arn:                              m.Arn,
+		CreationTime:                     m.CreationTime,
+		HttpPackageConfigurations:        m.HttpPackageConfigurations,
+		LastModifiedTime:                 m.LastModifiedTime,
+		SourceLocationName:               m.SourceLocationName,
+		Tags:                             m.Tags,
+		Name:                             m.Name,
+		AdBreakOpportunitiesOffsetMillis: m.AdBreakOpportunitiesOffsetMillis,
+		Timeouts:                         dataSourceTimeouts,
+	}
 }
@@ -2,17 +2,62 @@ package main
 
 import (
 	"context"
+	"flag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
 	"terraform-provider-mediatailor/awsmt"
+	"time"
 )
 
+const tracingShutdownTimeout = 2 * time.Second
+
 func main() {
-	err := providerserver.Serve(context.Background(), awsmt.New, providerserver.ServeOpts{
+	if len(os.Args) > 1 && os.Args[1] == "emulator" {
+		if err := serveEmulator(os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
+	ctx := context.Background()
+	shutdownTracing, err := awsmt.StartTracing(ctx)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(ctx, awsmt.New, providerserver.ServeOpts{
 
 		Address: "registry.terraform.io/spring-media/awsmt",
 	})
+
+	// Terraform stops the provider shortly after asking it to shut down, so the pending spans are flushed right away.
+	shutdownCtx, cancel := context.WithTimeout(ctx, tracingShutdownTimeout)
+	if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+		log.Printf("Failed to flush the traces: %s", shutdownErr)
+	}
+	cancel()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// serveEmulator serves the MediaTailor API from in-memory state until the process is stopped.
+func serveEmulator(args []string) error {
+	flags := flag.NewFlagSet("emulator", flag.ExitOnError)
+	host := flags.String("host", "127.0.0.1", "address to listen on")
+	port := flags.Int("port", 8080, "port to listen on")
+	region := flags.String("region", "eu-central-1", "region of the ARNs and URLs of the emulated resources")
+	accountID := flags.String("account-id", "123456789012", "account ID of the ARNs and URLs of the emulated resources")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	address := net.JoinHostPort(*host, strconv.Itoa(*port))
+	log.Printf("Serving the MediaTailor emulator on http://%s", address)
+	return http.ListenAndServe(address, awsmt.NewEmulator(*region, *accountID))
+}